@@ -0,0 +1,53 @@
+package dotenv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BoolVocabulary lists the spellings accepted for boolean values, compared case-insensitively.
+// The zero value accepts the spellings of DefaultBoolVocabulary.
+type BoolVocabulary struct {
+	// True lists the values read as true
+	True []string
+
+	// False lists the values read as false
+	False []string
+
+	// Strict parses booleans with strconv.ParseBool, ignoring True and False
+	Strict bool
+}
+
+// DefaultBoolVocabulary is used by the zero BoolVocabulary.
+// It extends the values of strconv.ParseBool with yes/no, on/off and enabled/disabled.
+var DefaultBoolVocabulary = BoolVocabulary{
+	True:  []string{"1", "t", "true", "y", "yes", "on", "enable", "enabled"},
+	False: []string{"0", "f", "false", "n", "no", "off", "disable", "disabled"},
+}
+
+// Parse returns the boolean represented by value.
+func (v BoolVocabulary) Parse(value string) (bool, error) {
+	if v.Strict {
+		return strconv.ParseBool(value)
+	}
+
+	if len(v.True) == 0 && len(v.False) == 0 {
+		v = DefaultBoolVocabulary
+	}
+
+	for _, candidate := range v.True {
+		if strings.EqualFold(value, candidate) {
+			return true, nil
+		}
+	}
+
+	for _, candidate := range v.False {
+		if strings.EqualFold(value, candidate) {
+			return false, nil
+		}
+	}
+
+	return false, fmt.Errorf("invalid boolean %q, expected one of %s or %s",
+		value, strings.Join(v.True, ", "), strings.Join(v.False, ", "))
+}
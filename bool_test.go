@@ -0,0 +1,88 @@
+package dotenv
+
+import (
+	"os"
+	"testing"
+)
+
+func TestBoolVocabulary_Parse(t *testing.T) {
+	custom := BoolVocabulary{True: []string{"oui"}, False: []string{"non"}}
+
+	tests := []struct {
+		vocabulary BoolVocabulary
+		value      string
+		expected   bool
+		wantErr    bool
+	}{
+		{BoolVocabulary{}, "true", true, false},
+		{BoolVocabulary{}, "YES", true, false},
+		{BoolVocabulary{}, "On", true, false},
+		{BoolVocabulary{}, "enabled", true, false},
+		{BoolVocabulary{}, "1", true, false},
+		{BoolVocabulary{}, "no", false, false},
+		{BoolVocabulary{}, "OFF", false, false},
+		{BoolVocabulary{}, "Disabled", false, false},
+		{BoolVocabulary{}, "0", false, false},
+		{BoolVocabulary{}, "maybe", false, true},
+		{BoolVocabulary{}, "", false, true},
+		{BoolVocabulary{Strict: true}, "TRUE", true, false},
+		{BoolVocabulary{Strict: true}, "yes", false, true},
+		{BoolVocabulary{Strict: true}, "off", false, true},
+		{custom, "OUI", true, false},
+		{custom, "non", false, false},
+		{custom, "yes", false, true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.vocabulary.Parse(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q) with %+v error = %v, wantErr %v", tt.value, tt.vocabulary, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("Parse(%q) with %+v = %v, want %v", tt.value, tt.vocabulary, got, tt.expected)
+		}
+	}
+}
+
+func TestLoadStruct_BoolVocabulary(t *testing.T) {
+	_ = os.Setenv("BOOL_FEATURE", "enabled")
+	_ = os.Setenv("BOOL_DEBUG", "off")
+	defer func() {
+		_ = os.Unsetenv("BOOL_FEATURE")
+		_ = os.Unsetenv("BOOL_DEBUG")
+	}()
+
+	type boolConfig struct {
+		Feature bool   `env:"BOOL_FEATURE"`
+		Debug   *bool  `env:"BOOL_DEBUG"`
+		Flags   []bool `env:"BOOL_FLAGS" default:"yes,no"`
+	}
+
+	config := &boolConfig{}
+	if err := LoadStruct(config); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !config.Feature || config.Debug == nil || *config.Debug || len(config.Flags) != 2 || !config.Flags[0] || config.Flags[1] {
+		t.Errorf("Unexpected values: %+v", config)
+	}
+
+	err := LoadStructWithOptions(&boolConfig{}, LoadOptions{Bools: BoolVocabulary{Strict: true}})
+	if err == nil {
+		t.Error("Expected error in strict mode, got nil")
+	}
+}
+
+func TestGetterBoolVocabulary(t *testing.T) {
+	lookuper := MapLookuper{"FEATURE": "on"}
+
+	if !NewGetter(lookuper).GetBool("FEATURE") {
+		t.Error("GetBool() should return true")
+	}
+
+	strict := NewGetterWithOptions(lookuper, GetterOptions{Bools: BoolVocabulary{Strict: true}})
+	if strict.GetBoolOrDefault("FEATURE", false) {
+		t.Error("GetBoolOrDefault() should return the default value in strict mode")
+	}
+}
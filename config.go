@@ -0,0 +1,90 @@
+package dotenv
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Config holds a struct loaded by LoadStructWithOptions, and replaces it atomically on Reload.
+// Readers call Get and never see a partially loaded struct, so they need no lock.
+// The struct returned by Get must be treated as read-only.
+type Config[T any] struct {
+	opts    LoadOptions
+	current atomic.Pointer[T]
+
+	// mu serializes reloads, so that subscribers are called in order
+	mu          sync.Mutex
+	subscribers []func(old, current *T)
+}
+
+// NewConfig loads T with the given options and returns a Config holding it.
+func NewConfig[T any](opts LoadOptions) (*Config[T], error) {
+	c := &Config[T]{opts: opts}
+
+	loaded, report, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	c.current.Store(loaded)
+	c.publish(report)
+
+	return c, nil
+}
+
+// Get returns the current struct.
+func (c *Config[T]) Get() *T {
+	return c.current.Load()
+}
+
+// Reload loads a new struct and swaps it in when it loads and validates without error.
+// On failure the current struct is kept and the error, a *LoadError, is returned.
+// Subscribers are called with the previous and the new struct after the swap.
+// When the options have a Report, it is replaced with the sources of the new struct,
+// so it should only be read from a subscriber.
+func (c *Config[T]) Reload() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	loaded, report, err := c.load()
+	if err != nil {
+		return err
+	}
+
+	old := c.current.Swap(loaded)
+	c.publish(report)
+	for _, subscriber := range c.subscribers {
+		subscriber(old, loaded)
+	}
+
+	return nil
+}
+
+// Subscribe registers fn to be called after every successful Reload.
+func (c *Config[T]) Subscribe(fn func(old, current *T)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.subscribers = append(c.subscribers, fn)
+}
+
+// load returns a new T populated with the options of the Config,
+// and the sources of its fields when the options have a Report.
+func (c *Config[T]) load() (*T, *Report, error) {
+	opts := c.opts
+	if opts.Report != nil {
+		opts.Report = &Report{}
+	}
+
+	loaded := new(T)
+	if err := LoadStructWithOptions(loaded, opts); err != nil {
+		return nil, nil, err
+	}
+	return loaded, opts.Report, nil
+}
+
+// publish replaces the content of the Report of the options with the sources of the last load.
+func (c *Config[T]) publish(report *Report) {
+	if c.opts.Report != nil {
+		*c.opts.Report = *report
+	}
+}
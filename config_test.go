@@ -0,0 +1,152 @@
+package dotenv
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+type holderConfig struct {
+	Feature bool `env:"HOLDER_FEATURE"`
+	Workers int  `env:"HOLDER_WORKERS" validator:"min=1"`
+}
+
+func TestConfig_Reload(t *testing.T) {
+	lookuper := MapLookuper{"HOLDER_FEATURE": "off", "HOLDER_WORKERS": "4"}
+
+	config, err := NewConfig[holderConfig](LoadOptions{Lookuper: lookuper})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	first := config.Get()
+	if *first != (holderConfig{Feature: false, Workers: 4}) {
+		t.Errorf("Get() = %+v", *first)
+	}
+
+	var notified []holderConfig
+	config.Subscribe(func(old, current *holderConfig) {
+		notified = append(notified, *old, *current)
+	})
+
+	lookuper["HOLDER_FEATURE"] = "on"
+	if err = config.Reload(); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	second := config.Get()
+	if *second != (holderConfig{Feature: true, Workers: 4}) || *first != (holderConfig{Feature: false, Workers: 4}) {
+		t.Errorf("Expected a new struct to be swapped in, got %+v, previous %+v", *second, *first)
+	}
+
+	if len(notified) != 2 || notified[0] != *first || notified[1] != *second {
+		t.Errorf("Unexpected notifications: %+v", notified)
+	}
+
+	lookuper["HOLDER_WORKERS"] = "0"
+	err = config.Reload()
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("Expected ErrValidation, got: %v", err)
+	}
+
+	if config.Get() != second || len(notified) != 2 {
+		t.Error("Expected the current struct to be kept after a failed reload")
+	}
+}
+
+func TestNewConfig_Error(t *testing.T) {
+	_, err := NewConfig[holderConfig](LoadOptions{Lookuper: MapLookuper{"HOLDER_WORKERS": "many"}})
+	if !errors.Is(err, ErrParse) {
+		t.Errorf("Expected ErrParse, got: %v", err)
+	}
+}
+
+func TestNewConfig_NonStruct(t *testing.T) {
+	if _, err := NewConfig[int](LoadOptions{}); err == nil {
+		t.Error("Expected an error for a non struct type, got nil")
+	}
+}
+
+func TestConfig_ConcurrentReload(t *testing.T) {
+	config, err := NewConfig[holderConfig](LoadOptions{Lookuper: MapLookuper{"HOLDER_WORKERS": "2"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = config.Reload()
+		}()
+		go func() {
+			defer wg.Done()
+			if config.Get().Workers != 2 {
+				t.Error("Expected Workers to be 2")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConfig_Watcher(t *testing.T) {
+	location := filepath.Join(t.TempDir(), ".env")
+	writeWatchedFile(t, location, "HOLDER_WORKERS=2\n")
+
+	watcher, err := NewWatcher(location)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	config, err := NewConfig[holderConfig](LoadOptions{Lookuper: watcher})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	watcher.Subscribe(func([]Change) {
+		if err := config.Reload(); err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+	})
+
+	writeWatchedFile(t, location, "HOLDER_WORKERS=8\n")
+	if _, err = watcher.Check(); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.Get().Workers != 8 {
+		t.Errorf("Expected Workers to be reloaded, got %d", config.Get().Workers)
+	}
+}
+
+func TestConfig_ReloadReport(t *testing.T) {
+	lookuper := MapLookuper{"HOLDER_WORKERS": "2"}
+	report := &Report{}
+
+	config, err := NewConfig[holderConfig](LoadOptions{Lookuper: lookuper, Report: report})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for _, workers := range []string{"3", "4"} {
+		lookuper["HOLDER_WORKERS"] = workers
+		if err = config.Reload(); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+
+	lookuper["HOLDER_WORKERS"] = "0"
+	if err = config.Reload(); err == nil {
+		t.Fatal("Expected reload error, got nil")
+	}
+
+	if len(report.Sources) != 2 {
+		t.Fatalf("Expected the sources of a single load, got %d", len(report.Sources))
+	}
+
+	if source, _ := report.Get("HOLDER_WORKERS"); source.Value != "4" {
+		t.Errorf("Expected the report of the last successful load, got %q", source.Value)
+	}
+}
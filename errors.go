@@ -0,0 +1,179 @@
+package dotenv
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrRequired is reported when a required environment variable is not set.
+	ErrRequired = errors.New("required environment variable is not set")
+	// ErrParse is reported when a value cannot be converted to the field type.
+	ErrParse = errors.New("invalid value")
+	// ErrValidation is reported when a value is rejected by a validator.
+	ErrValidation = errors.New("validation failed")
+	// ErrUnsupportedType is reported when a field type cannot be loaded.
+	ErrUnsupportedType = errors.New("unsupported type")
+	// ErrConflict is reported when mutually exclusive environment variables are set together.
+	ErrConflict = errors.New("conflicting environment variables")
+)
+
+// FieldError describes why a single struct field could not be loaded.
+type FieldError struct {
+	// Field is the Go path of the field, e.g. "Database.Port", empty for the root struct
+	Field string
+	// Key is the environment variable the field is loaded from, prefix included, empty for a struct
+	Key string
+	// Value is the raw value that was being loaded, empty when the variable is not set.
+	// It is redacted when Secret is true.
+	Value string
+	// Secret reports whether the field is tagged secret:"true"
+	Secret bool
+	// Kind is one of ErrRequired, ErrParse, ErrValidation, ErrUnsupportedType or ErrConflict
+	Kind error
+	// Err is the underlying cause
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap exposes both the kind of failure and the underlying cause,
+// so errors.Is(err, ErrParse) and errors.As on the cause both work.
+func (e *FieldError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// LoadError gathers every FieldError met while loading a struct.
+type LoadError struct {
+	Errors []*FieldError
+}
+
+// Error returns the message of the only error, or a report listing every field when there are several.
+func (e *LoadError) Error() string {
+	header := fmt.Sprintf("%d errors occurred while loading struct:", len(e.Errors))
+
+	return reportMessage(header, e.Errors, func(err *FieldError) string {
+		switch {
+		case err.Key == "" && err.Field == "":
+			return err.Err.Error()
+		case err.Key == "":
+			return fmt.Sprintf("%s: %s", err.Field, err.Err)
+		case err.Value != "":
+			return fmt.Sprintf("%s (%s=%q): %s", err.Field, err.Key, err.Value, err.Err)
+		default:
+			return fmt.Sprintf("%s (%s): %s", err.Field, err.Key, err.Err)
+		}
+	})
+}
+
+// Unwrap exposes every FieldError to errors.Is and errors.As.
+func (e *LoadError) Unwrap() []error {
+	return unwrapAll(e.Errors)
+}
+
+// reportMessage returns the message of the only error of a report,
+// or the header followed by one line per error when there are several.
+func reportMessage[E error](header string, errs []E, line func(err E) string) string {
+	if len(errs) == 1 {
+		return errs[0].Error()
+	}
+
+	var builder strings.Builder
+	builder.WriteString(header)
+
+	for _, err := range errs {
+		builder.WriteString(linebreak())
+		builder.WriteString("  - ")
+		builder.WriteString(line(err))
+	}
+
+	return builder.String()
+}
+
+// unwrapAll returns the errors of a report as the []error of an Unwrap method.
+func unwrapAll[E error](errs []E) []error {
+	result := make([]error, len(errs))
+	for i, err := range errs {
+		result[i] = err
+	}
+	return result
+}
+
+// redactedError is the error of a secret field, without the secret value in its message or causes.
+type redactedError struct {
+	msg    string
+	causes []error
+}
+
+// redactError returns err with the parts of the secret value redacted where its message quotes them,
+// as in `strconv.ParseInt: parsing "****": invalid syntax`, in range errors, and anywhere for long values.
+// The original error is not exposed, only redacted copies of its *strconv.NumError and range error,
+// so errors.Is(err, strconv.ErrSyntax) and errors.Is(err, strconv.ErrRange) still work.
+func redactError(err error, value string) error {
+	redacted := &redactedError{msg: err.Error()}
+
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		safe := *numErr
+		safe.Num = Redact(numErr.Num)
+		redacted.causes = append(redacted.causes, &safe)
+	}
+
+	var rangeErr *rangeError
+	if errors.As(err, &rangeErr) {
+		safe := *rangeErr
+		safe.value = Redact(rangeErr.value)
+		redacted.msg = strings.ReplaceAll(redacted.msg, rangeErr.Error(), safe.Error())
+		redacted.causes = append(redacted.causes, &safe)
+	}
+
+	redacted.msg = redactQuoted(redacted.msg, value)
+	// A long value is unlikely to appear by chance in the text, so it is also redacted when not quoted
+	if utf8.RuneCountInString(value) > 2*redactVisible {
+		redacted.msg = strings.ReplaceAll(redacted.msg, value, Redact(value))
+	}
+	return redacted
+}
+
+// redactQuoted redacts the quoted strings of msg that are part of the secret value.
+// Other occurrences of the value are kept, so that a short secret does not mask the rest of the message.
+func redactQuoted(msg, value string) string {
+	var builder strings.Builder
+
+	for {
+		start := strings.IndexByte(msg, '"')
+		if start < 0 {
+			break
+		}
+		builder.WriteString(msg[:start])
+
+		quoted, err := strconv.QuotedPrefix(msg[start:])
+		if err != nil {
+			builder.WriteByte('"')
+			msg = msg[start+1:]
+			continue
+		}
+		msg = msg[start+len(quoted):]
+
+		if unquoted, _ := strconv.Unquote(quoted); unquoted != "" && strings.Contains(value, unquoted) {
+			quoted = strconv.Quote(Redact(unquoted))
+		}
+		builder.WriteString(quoted)
+	}
+
+	builder.WriteString(msg)
+	return builder.String()
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() []error {
+	return e.causes
+}
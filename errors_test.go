@@ -0,0 +1,176 @@
+package dotenv
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLoadStruct_AggregatesErrors(t *testing.T) {
+	_ = os.Setenv("AGG_PORT", "80x")
+	_ = os.Setenv("AGG_DEBUG", "maybe")
+
+	defer func() {
+		_ = os.Unsetenv("AGG_PORT")
+		_ = os.Unsetenv("AGG_DEBUG")
+	}()
+
+	config := &struct {
+		APIKey   string `env:"AGG_API_KEY" required:"true"`
+		Name     string `env:"AGG_NAME" default:"dotenv"`
+		Database struct {
+			Port int `env:"AGG_PORT"`
+		}
+		Debug bool `env:"AGG_DEBUG"`
+	}{}
+
+	err := LoadStruct(config)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) {
+		t.Fatalf("Expected a *LoadError, got: %T", err)
+	}
+
+	if len(loadErr.Errors) != 3 {
+		t.Fatalf("Expected 3 field errors, got %d: %v", len(loadErr.Errors), err)
+	}
+
+	portErr := loadErr.Errors[1]
+	if portErr.Field != "Database.Port" || portErr.Key != "AGG_PORT" || portErr.Value != "80x" {
+		t.Errorf("Unexpected field error: %+v", portErr)
+	}
+
+	// Fields after a failing one are still loaded
+	if config.Name != "dotenv" {
+		t.Errorf("Expected Name to be 'dotenv', got: %s", config.Name)
+	}
+
+	msg := err.Error()
+	for _, want := range []string{
+		"3 errors occurred while loading struct:",
+		"APIKey (AGG_API_KEY): required environment variable AGG_API_KEY is not set",
+		`Database.Port (AGG_PORT="80x"): failed to parse int field Port`,
+		`Debug (AGG_DEBUG="maybe"): failed to parse bool field Debug`,
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected error report to contain %q, got:\n%s", want, msg)
+		}
+	}
+}
+
+func TestLoadError_SingleErrorMessage(t *testing.T) {
+	config := &struct {
+		APIKey string `env:"AGG_MISSING_KEY" required:"true"`
+	}{}
+
+	err := LoadStruct(config)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if err.Error() != "required environment variable AGG_MISSING_KEY is not set" {
+		t.Errorf("Unexpected error message: %s", err.Error())
+	}
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Key != "AGG_MISSING_KEY" {
+		t.Errorf("Expected errors.As to find the *FieldError, got: %v", fieldErr)
+	}
+}
+
+func TestLoadStruct_SentinelErrors(t *testing.T) {
+	_ = os.Setenv("KIND_PORT", "80x")
+	_ = os.Setenv("KIND_EMAIL", "invalid")
+
+	defer func() {
+		_ = os.Unsetenv("KIND_PORT")
+		_ = os.Unsetenv("KIND_EMAIL")
+	}()
+
+	tests := []struct {
+		name   string
+		config interface{}
+		kind   error
+	}{
+		{
+			name: "missing required variable",
+			config: &struct {
+				Key string `env:"KIND_MISSING" required:"true"`
+			}{},
+			kind: ErrRequired,
+		},
+		{
+			name: "unparsable value",
+			config: &struct {
+				Port int `env:"KIND_PORT"`
+			}{},
+			kind: ErrParse,
+		},
+		{
+			name: "rejected by validator",
+			config: &struct {
+				Email string `env:"KIND_EMAIL" validator:"fail"`
+			}{},
+			kind: ErrValidation,
+		},
+		{
+			name: "unsupported type",
+			config: &struct {
+				Values chan int `env:"KIND_PORT"`
+			}{},
+			kind: ErrUnsupportedType,
+		},
+	}
+
+	opts := LoadOptions{
+		Validators: map[string]Validator{
+			"fail": func(value reflect.Value) error {
+				return errors.New("always fails")
+			},
+		},
+	}
+
+	kinds := []error{ErrRequired, ErrParse, ErrValidation, ErrUnsupportedType, ErrConflict}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := LoadStructWithOptions(tt.config, opts)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+
+			for _, kind := range kinds {
+				if got := errors.Is(err, kind); got != (kind == tt.kind) {
+					t.Errorf("errors.Is(err, %v) = %t", kind, got)
+				}
+			}
+
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Kind != tt.kind {
+				t.Errorf("Expected *FieldError with kind %v, got: %+v", tt.kind, fieldErr)
+			}
+		})
+	}
+}
+
+func TestLoadStruct_ParseErrorUnwrapsCause(t *testing.T) {
+	_ = os.Setenv("KIND_CAUSE", "80x")
+	defer func() { _ = os.Unsetenv("KIND_CAUSE") }()
+
+	config := &struct {
+		Port int `env:"KIND_CAUSE"`
+	}{}
+
+	err := LoadStruct(config)
+
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		t.Fatalf("Expected errors.As to find the *strconv.NumError, got: %v", err)
+	}
+}
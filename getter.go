@@ -1,169 +1,654 @@
 package dotenv
 
 import (
-	"os"
+	"fmt"
+	"net"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
+// FallbackPolicy tells when the OrDefault getters return their default value.
+type FallbackPolicy int
+
+const (
+	// FallbackOnInvalid returns the default value when the variable is unset, empty or invalid.
+	FallbackOnInvalid FallbackPolicy = iota
+	// FallbackOnEmpty returns the default value when the variable is unset or empty.
+	// An invalid value gives the zero value.
+	FallbackOnEmpty
+	// FallbackOnUnset returns the default value only when the variable is unset.
+	// An empty or invalid value gives the zero value, or the empty string for GetString.
+	FallbackOnUnset
+)
+
+// GetterOptions provides configuration options for NewGetterWithOptions
+type GetterOptions struct {
+	// Policy tells when the OrDefault getters return their default value, FallbackOnInvalid by default
+	Policy FallbackPolicy
+
+	// OnInvalid, when not nil, is called with every value that cannot be converted,
+	// instead of silently ignoring it
+	OnInvalid func(key, value string, err error)
+
+	// Bools is the vocabulary of GetBool and GetBoolOrDefault, DefaultBoolVocabulary when empty
+	Bools BoolVocabulary
+
+	// AutoBase lets integer getters read the 0x, 0o and 0b prefixes and underscores,
+	// e.g. 0xff or 1_000_000, as the base:"0" tag does. A leading 0 then means octal.
+	AutoBase bool
+}
+
+// Getter retrieves typed values from a Lookuper.
+// The package level getters use a Getter reading the process environment with FallbackOnInvalid.
+type Getter struct {
+	lookuper Lookuper
+	opts     GetterOptions
+}
+
+// NewGetter returns a Getter reading variables from the given Lookuper.
+func NewGetter(lookuper Lookuper) *Getter {
+	return NewGetterWithOptions(lookuper, GetterOptions{})
+}
+
+// NewGetterWithOptions returns a Getter reading variables from the given Lookuper with additional options.
+func NewGetterWithOptions(lookuper Lookuper, opts GetterOptions) *Getter {
+	return &Getter{lookuper: lookuper, opts: opts}
+}
+
+// base returns the integer base given to strconv.
+func (g *Getter) base() int {
+	if g.opts.AutoBase {
+		return 0
+	}
+	return 10
+}
+
+// defaultGetter is used by the package level getters.
+var defaultGetter = NewGetter(OSLookuper{})
+
+// getOrDefault converts the variable named by the key with parse,
+// returning the default value according to the fallback policy of the Getter.
+func getOrDefault[T any](g *Getter, key string, defaultValue T, parse func(string) (T, error)) T {
+	value, exists := g.lookuper.Lookup(key)
+	if !exists || (value == "" && g.opts.Policy != FallbackOnUnset) {
+		return defaultValue
+	}
+
+	result, err := parse(value)
+	if err != nil {
+		if g.opts.OnInvalid != nil {
+			g.opts.OnInvalid(key, value, err)
+		}
+		if g.opts.Policy == FallbackOnInvalid {
+			return defaultValue
+		}
+		var zero T
+		return zero
+	}
+
+	return result
+}
+
+func parseString(value string) (string, error) {
+	return value, nil
+}
+
+func parseFloat32(value string) (float32, error) {
+	result, err := strconv.ParseFloat(value, 32)
+	return float32(result), err
+}
+
+func parseFloat64(value string) (float64, error) {
+	return strconv.ParseFloat(value, 64)
+}
+
+// signed returns a parser of T using the integer base of the Getter.
+func signed[T int | int8 | int16 | int32 | int64](g *Getter, bitSize int) func(string) (T, error) {
+	return func(value string) (T, error) {
+		result, err := strconv.ParseInt(value, g.base(), bitSize)
+		return T(result), err
+	}
+}
+
+// unsigned returns a parser of T using the integer base of the Getter.
+func unsigned[T uint | uint8 | uint16 | uint32 | uint64](g *Getter, bitSize int) func(string) (T, error) {
+	return func(value string) (T, error) {
+		result, err := strconv.ParseUint(value, g.base(), bitSize)
+		return T(result), err
+	}
+}
+
+func parseIP(value string) (net.IP, error) {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %q", value)
+	}
+	return ip, nil
+}
+
+// parseStringSlice splits a comma separated value, trimming every element.
+// A blank value is an empty slice, as for slice fields.
+func parseStringSlice(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return []string{}, nil
+	}
+
+	parts := strings.Split(value, defaultSeparator)
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return parts, nil
+}
+
+func (g *Getter) parseIntSlice(value string) ([]int, error) {
+	parts, _ := parseStringSlice(value)
+	parse := signed[int](g, 0)
+
+	result := make([]int, len(parts))
+	for i, part := range parts {
+		number, err := parse(part)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = number
+	}
+	return result, nil
+}
+
+// parseStringMap parses comma separated key=value pairs, e.g. "region=eu,tier=gold".
+func parseStringMap(value string) (map[string]string, error) {
+	parts, _ := parseStringSlice(value)
+
+	result := make(map[string]string, len(parts))
+	for _, part := range parts {
+		key, val, found := strings.Cut(part, defaultKeyValueSeparator)
+		if !found {
+			return nil, fmt.Errorf("missing %q in %q", defaultKeyValueSeparator, part)
+		}
+		result[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	return result, nil
+}
+
 // GetString returns the value in string format of the environment variable named by the key.
 func GetString(key string) string {
-	return os.Getenv(key)
+	return defaultGetter.GetString(key)
+}
+
+// GetString returns the value in string format of the variable named by the key.
+func (g *Getter) GetString(key string) string {
+	return getOrDefault(g, key, "", parseString)
 }
 
 // GetStringOrDefault returns the value in string format or the default value if the environment variable is not set.
 func GetStringOrDefault(key, defaultValue string) string {
-	value := GetString(key)
-	if value == "" {
-		return defaultValue
-	}
+	return defaultGetter.GetStringOrDefault(key, defaultValue)
+}
+
+// GetStringOrDefault returns the value in string format or the default value according to the fallback policy.
+func (g *Getter) GetStringOrDefault(key, defaultValue string) string {
+	return getOrDefault(g, key, defaultValue, parseString)
+}
+
+// GetStringFromFile returns the value of the environment variable named by the key,
+// or the content of the file named by key+"_FILE" when the key is not set, e.g. a Docker secret.
+// It returns an empty string when both are set or the file cannot be read, see LookupStringFromFile for the error.
+func GetStringFromFile(key string) string {
+	return defaultGetter.GetStringFromFile(key)
+}
+
+// GetStringFromFile returns the value of the variable named by the key,
+// or the content of the file named by key+"_FILE" when the key is not set.
+// It returns an empty string when both are set or the file cannot be read.
+func (g *Getter) GetStringFromFile(key string) string {
+	value, _, _ := g.LookupStringFromFile(key)
 	return value
 }
 
-// GetInt returns the value in int format of the environment variable named by the key.
-func GetInt(key string) int {
-	result, err := strconv.Atoi(os.Getenv(key))
+// LookupStringFromFile is GetStringFromFile reporting whether the key or key+"_FILE" is set, and why it failed.
+// The error is a *FieldError matching ErrConflict when both are set, or ErrParse with the read error.
+func LookupStringFromFile(key string) (string, bool, error) {
+	return defaultGetter.LookupStringFromFile(key)
+}
+
+// LookupStringFromFile is GetStringFromFile reporting whether the key or key+"_FILE" is set, and why it failed.
+func (g *Getter) LookupStringFromFile(key string) (string, bool, error) {
+	value, exists := g.lookuper.Lookup(key)
+	fileKey := key + defaultFileSuffix
+	location, fileExists := g.lookuper.Lookup(fileKey)
+
+	if !fileExists {
+		return value, exists, nil
+	}
+	if exists {
+		return "", true, &FieldError{Key: key, Kind: ErrConflict, Err: fmt.Errorf("both %s and %s are set", key, fileKey)}
+	}
+
+	value, err := readValueFile(location)
 	if err != nil {
-		return 0
+		return "", true, &FieldError{Key: fileKey, Value: location, Kind: ErrParse, Err: fmt.Errorf("failed to read file for %s: %w", key, err)}
 	}
-	return result
+	return value, true, nil
+}
+
+// GetInt returns the value in int format of the environment variable named by the key.
+func GetInt(key string) int {
+	return defaultGetter.GetInt(key)
+}
+
+// GetInt returns the value in int format of the variable named by the key.
+func (g *Getter) GetInt(key string) int {
+	return getOrDefault(g, key, 0, signed[int](g, 0))
 }
 
 // GetIntOrDefault returns the value in int format or the default value if the environment variable is not set.
 func GetIntOrDefault(key string, defaultValue int) int {
-	result, err := strconv.Atoi(os.Getenv(key))
-	if err != nil {
-		return defaultValue
-	}
-	return result
+	return defaultGetter.GetIntOrDefault(key, defaultValue)
+}
+
+// GetIntOrDefault returns the value in int format or the default value according to the fallback policy.
+func (g *Getter) GetIntOrDefault(key string, defaultValue int) int {
+	return getOrDefault(g, key, defaultValue, signed[int](g, 0))
 }
 
 // GetBool returns the value in bool format of the environment variable named by the key.
 func GetBool(key string) bool {
-	result, err := strconv.ParseBool(os.Getenv(key))
-	if err != nil {
-		return false
-	}
-	return result
+	return defaultGetter.GetBool(key)
+}
+
+// GetBool returns the value in bool format of the variable named by the key.
+func (g *Getter) GetBool(key string) bool {
+	return getOrDefault(g, key, false, g.opts.Bools.Parse)
 }
 
 // GetBoolOrDefault returns the value in bool format or the default value if the environment variable is not set.
 func GetBoolOrDefault(key string, defaultValue bool) bool {
-	result, err := strconv.ParseBool(os.Getenv(key))
-	if err != nil {
-		return defaultValue
-	}
-	return result
+	return defaultGetter.GetBoolOrDefault(key, defaultValue)
+}
+
+// GetBoolOrDefault returns the value in bool format or the default value according to the fallback policy.
+func (g *Getter) GetBoolOrDefault(key string, defaultValue bool) bool {
+	return getOrDefault(g, key, defaultValue, g.opts.Bools.Parse)
 }
 
 // GetFloat64 returns the value in float64 format of the environment variable named by the key.
 func GetFloat64(key string) float64 {
-	result, err := strconv.ParseFloat(os.Getenv(key), 64)
-	if err != nil {
-		return 0
-	}
-	return result
+	return defaultGetter.GetFloat64(key)
+}
+
+// GetFloat64 returns the value in float64 format of the variable named by the key.
+func (g *Getter) GetFloat64(key string) float64 {
+	return getOrDefault(g, key, 0, parseFloat64)
 }
 
 // GetFloat64OrDefault returns the value in float64 format or the default value if the environment variable is not set.
 func GetFloat64OrDefault(key string, defaultValue float64) float64 {
-	value, exists := os.LookupEnv(key)
-	if !exists || value == "" {
-		return defaultValue
-	}
+	return defaultGetter.GetFloat64OrDefault(key, defaultValue)
+}
 
-	result, err := strconv.ParseFloat(value, 64)
-	if err != nil {
-		return defaultValue
-	}
-	return result
+// GetFloat64OrDefault returns the value in float64 format or the default value according to the fallback policy.
+func (g *Getter) GetFloat64OrDefault(key string, defaultValue float64) float64 {
+	return getOrDefault(g, key, defaultValue, parseFloat64)
 }
 
 // GetFloat32 returns the value in float32 format of the environment variable named by the key.
 func GetFloat32(key string) float32 {
-	value := os.Getenv(key)
-	if value == "" {
-		return 0
-	}
-
-	result, err := strconv.ParseFloat(value, 32)
-	if err != nil {
-		return 0
-	}
+	return defaultGetter.GetFloat32(key)
+}
 
-	return float32(result)
+// GetFloat32 returns the value in float32 format of the variable named by the key.
+func (g *Getter) GetFloat32(key string) float32 {
+	return getOrDefault(g, key, 0, parseFloat32)
 }
 
 // GetFloat32OrDefault returns the value in float32 format or the default value.
 func GetFloat32OrDefault(key string, defaultValue float32) float32 {
-	value, exists := os.LookupEnv(key)
-	if !exists || value == "" {
-		return defaultValue
-	}
-
-	result, err := strconv.ParseFloat(value, 32)
-	if err != nil {
-		return defaultValue
-	}
+	return defaultGetter.GetFloat32OrDefault(key, defaultValue)
+}
 
-	return float32(result)
+// GetFloat32OrDefault returns the value in float32 format or the default value according to the fallback policy.
+func (g *Getter) GetFloat32OrDefault(key string, defaultValue float32) float32 {
+	return getOrDefault(g, key, defaultValue, parseFloat32)
 }
 
 // GetUint returns the value in uint format of the environment variable named by the key.
 func GetUint(key string) uint {
-	value := os.Getenv(key)
-	if value == "" {
-		return 0
-	}
-
-	result, err := strconv.ParseUint(value, 10, 0)
-	if err != nil {
-		return 0
-	}
+	return defaultGetter.GetUint(key)
+}
 
-	return uint(result)
+// GetUint returns the value in uint format of the variable named by the key.
+func (g *Getter) GetUint(key string) uint {
+	return getOrDefault(g, key, 0, unsigned[uint](g, 0))
 }
 
 // GetUintOrDefault returns the value in uint format or the default value.
 func GetUintOrDefault(key string, defaultValue uint) uint {
-	value, exists := os.LookupEnv(key)
-	if !exists || value == "" {
-		return defaultValue
-	}
-
-	result, err := strconv.ParseUint(value, 10, 0)
-	if err != nil {
-		return defaultValue
-	}
+	return defaultGetter.GetUintOrDefault(key, defaultValue)
+}
 
-	return uint(result)
+// GetUintOrDefault returns the value in uint format or the default value according to the fallback policy.
+func (g *Getter) GetUintOrDefault(key string, defaultValue uint) uint {
+	return getOrDefault(g, key, defaultValue, unsigned[uint](g, 0))
 }
 
 // GetInt64 returns the value in int64 format of the environment variable named by the key.
 func GetInt64(key string) int64 {
-	value := os.Getenv(key)
-	if value == "" {
-		return 0
-	}
-
-	result, err := strconv.ParseInt(value, 10, 64)
-	if err != nil {
-		return 0
-	}
+	return defaultGetter.GetInt64(key)
+}
 
-	return result
+// GetInt64 returns the value in int64 format of the variable named by the key.
+func (g *Getter) GetInt64(key string) int64 {
+	return getOrDefault(g, key, 0, signed[int64](g, 64))
 }
 
 // GetInt64OrDefault returns the value in int64 format or the default value.
 func GetInt64OrDefault(key string, defaultValue int64) int64 {
-	value, exists := os.LookupEnv(key)
-	if !exists || value == "" {
-		return defaultValue
-	}
+	return defaultGetter.GetInt64OrDefault(key, defaultValue)
+}
 
-	result, err := strconv.ParseInt(value, 10, 64)
-	if err != nil {
-		return defaultValue
-	}
+// GetInt64OrDefault returns the value in int64 format or the default value according to the fallback policy.
+func (g *Getter) GetInt64OrDefault(key string, defaultValue int64) int64 {
+	return getOrDefault(g, key, defaultValue, signed[int64](g, 64))
+}
 
-	return result
+// GetInt8 returns the value in int8 format of the environment variable named by the key.
+func GetInt8(key string) int8 {
+	return defaultGetter.GetInt8(key)
+}
+
+// GetInt8 returns the value in int8 format of the variable named by the key.
+func (g *Getter) GetInt8(key string) int8 {
+	return getOrDefault(g, key, 0, signed[int8](g, 8))
+}
+
+// GetInt8OrDefault returns the value in int8 format or the default value.
+func GetInt8OrDefault(key string, defaultValue int8) int8 {
+	return defaultGetter.GetInt8OrDefault(key, defaultValue)
+}
+
+// GetInt8OrDefault returns the value in int8 format or the default value according to the fallback policy.
+func (g *Getter) GetInt8OrDefault(key string, defaultValue int8) int8 {
+	return getOrDefault(g, key, defaultValue, signed[int8](g, 8))
+}
+
+// GetInt16 returns the value in int16 format of the environment variable named by the key.
+func GetInt16(key string) int16 {
+	return defaultGetter.GetInt16(key)
+}
+
+// GetInt16 returns the value in int16 format of the variable named by the key.
+func (g *Getter) GetInt16(key string) int16 {
+	return getOrDefault(g, key, 0, signed[int16](g, 16))
+}
+
+// GetInt16OrDefault returns the value in int16 format or the default value.
+func GetInt16OrDefault(key string, defaultValue int16) int16 {
+	return defaultGetter.GetInt16OrDefault(key, defaultValue)
+}
+
+// GetInt16OrDefault returns the value in int16 format or the default value according to the fallback policy.
+func (g *Getter) GetInt16OrDefault(key string, defaultValue int16) int16 {
+	return getOrDefault(g, key, defaultValue, signed[int16](g, 16))
+}
+
+// GetInt32 returns the value in int32 format of the environment variable named by the key.
+func GetInt32(key string) int32 {
+	return defaultGetter.GetInt32(key)
+}
+
+// GetInt32 returns the value in int32 format of the variable named by the key.
+func (g *Getter) GetInt32(key string) int32 {
+	return getOrDefault(g, key, 0, signed[int32](g, 32))
+}
+
+// GetInt32OrDefault returns the value in int32 format or the default value.
+func GetInt32OrDefault(key string, defaultValue int32) int32 {
+	return defaultGetter.GetInt32OrDefault(key, defaultValue)
+}
+
+// GetInt32OrDefault returns the value in int32 format or the default value according to the fallback policy.
+func (g *Getter) GetInt32OrDefault(key string, defaultValue int32) int32 {
+	return getOrDefault(g, key, defaultValue, signed[int32](g, 32))
+}
+
+// GetUint8 returns the value in uint8 format of the environment variable named by the key.
+func GetUint8(key string) uint8 {
+	return defaultGetter.GetUint8(key)
+}
+
+// GetUint8 returns the value in uint8 format of the variable named by the key.
+func (g *Getter) GetUint8(key string) uint8 {
+	return getOrDefault(g, key, 0, unsigned[uint8](g, 8))
+}
+
+// GetUint8OrDefault returns the value in uint8 format or the default value.
+func GetUint8OrDefault(key string, defaultValue uint8) uint8 {
+	return defaultGetter.GetUint8OrDefault(key, defaultValue)
+}
+
+// GetUint8OrDefault returns the value in uint8 format or the default value according to the fallback policy.
+func (g *Getter) GetUint8OrDefault(key string, defaultValue uint8) uint8 {
+	return getOrDefault(g, key, defaultValue, unsigned[uint8](g, 8))
+}
+
+// GetUint16 returns the value in uint16 format of the environment variable named by the key.
+func GetUint16(key string) uint16 {
+	return defaultGetter.GetUint16(key)
+}
+
+// GetUint16 returns the value in uint16 format of the variable named by the key.
+func (g *Getter) GetUint16(key string) uint16 {
+	return getOrDefault(g, key, 0, unsigned[uint16](g, 16))
+}
+
+// GetUint16OrDefault returns the value in uint16 format or the default value.
+func GetUint16OrDefault(key string, defaultValue uint16) uint16 {
+	return defaultGetter.GetUint16OrDefault(key, defaultValue)
+}
+
+// GetUint16OrDefault returns the value in uint16 format or the default value according to the fallback policy.
+func (g *Getter) GetUint16OrDefault(key string, defaultValue uint16) uint16 {
+	return getOrDefault(g, key, defaultValue, unsigned[uint16](g, 16))
+}
+
+// GetUint32 returns the value in uint32 format of the environment variable named by the key.
+func GetUint32(key string) uint32 {
+	return defaultGetter.GetUint32(key)
+}
+
+// GetUint32 returns the value in uint32 format of the variable named by the key.
+func (g *Getter) GetUint32(key string) uint32 {
+	return getOrDefault(g, key, 0, unsigned[uint32](g, 32))
+}
+
+// GetUint32OrDefault returns the value in uint32 format or the default value.
+func GetUint32OrDefault(key string, defaultValue uint32) uint32 {
+	return defaultGetter.GetUint32OrDefault(key, defaultValue)
+}
+
+// GetUint32OrDefault returns the value in uint32 format or the default value according to the fallback policy.
+func (g *Getter) GetUint32OrDefault(key string, defaultValue uint32) uint32 {
+	return getOrDefault(g, key, defaultValue, unsigned[uint32](g, 32))
+}
+
+// GetUint64 returns the value in uint64 format of the environment variable named by the key.
+func GetUint64(key string) uint64 {
+	return defaultGetter.GetUint64(key)
+}
+
+// GetUint64 returns the value in uint64 format of the variable named by the key.
+func (g *Getter) GetUint64(key string) uint64 {
+	return getOrDefault(g, key, 0, unsigned[uint64](g, 64))
+}
+
+// GetUint64OrDefault returns the value in uint64 format or the default value.
+func GetUint64OrDefault(key string, defaultValue uint64) uint64 {
+	return defaultGetter.GetUint64OrDefault(key, defaultValue)
+}
+
+// GetUint64OrDefault returns the value in uint64 format or the default value according to the fallback policy.
+func (g *Getter) GetUint64OrDefault(key string, defaultValue uint64) uint64 {
+	return getOrDefault(g, key, defaultValue, unsigned[uint64](g, 64))
+}
+
+// GetDuration returns the value in time.Duration format of the environment variable named by the key.
+func GetDuration(key string) time.Duration {
+	return defaultGetter.GetDuration(key)
+}
+
+// GetDuration returns the value in time.Duration format of the variable named by the key.
+func (g *Getter) GetDuration(key string) time.Duration {
+	return getOrDefault(g, key, 0, time.ParseDuration)
+}
+
+// GetDurationOrDefault returns the value in time.Duration format or the default value.
+func GetDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	return defaultGetter.GetDurationOrDefault(key, defaultValue)
+}
+
+// GetDurationOrDefault returns the value in time.Duration format or the default value according to the fallback policy.
+func (g *Getter) GetDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	return getOrDefault(g, key, defaultValue, time.ParseDuration)
+}
+
+// GetURL returns the value in *url.URL format of the environment variable named by the key.
+func GetURL(key string) *url.URL {
+	return defaultGetter.GetURL(key)
+}
+
+// GetURL returns the value in *url.URL format of the variable named by the key.
+func (g *Getter) GetURL(key string) *url.URL {
+	return getOrDefault(g, key, nil, url.Parse)
+}
+
+// GetURLOrDefault returns the value in *url.URL format or the default value.
+func GetURLOrDefault(key string, defaultValue *url.URL) *url.URL {
+	return defaultGetter.GetURLOrDefault(key, defaultValue)
+}
+
+// GetURLOrDefault returns the value in *url.URL format or the default value according to the fallback policy.
+func (g *Getter) GetURLOrDefault(key string, defaultValue *url.URL) *url.URL {
+	return getOrDefault(g, key, defaultValue, url.Parse)
+}
+
+// GetIP returns the value in net.IP format of the environment variable named by the key.
+func GetIP(key string) net.IP {
+	return defaultGetter.GetIP(key)
+}
+
+// GetIP returns the value in net.IP format of the variable named by the key.
+func (g *Getter) GetIP(key string) net.IP {
+	return getOrDefault(g, key, nil, parseIP)
+}
+
+// GetIPOrDefault returns the value in net.IP format or the default value.
+func GetIPOrDefault(key string, defaultValue net.IP) net.IP {
+	return defaultGetter.GetIPOrDefault(key, defaultValue)
+}
+
+// GetIPOrDefault returns the value in net.IP format or the default value according to the fallback policy.
+func (g *Getter) GetIPOrDefault(key string, defaultValue net.IP) net.IP {
+	return getOrDefault(g, key, defaultValue, parseIP)
+}
+
+// GetStringSlice returns the value in comma separated []string format of the environment variable named by the key.
+func GetStringSlice(key string) []string {
+	return defaultGetter.GetStringSlice(key)
+}
+
+// GetStringSlice returns the value in comma separated []string format of the variable named by the key.
+func (g *Getter) GetStringSlice(key string) []string {
+	return getOrDefault(g, key, nil, parseStringSlice)
+}
+
+// GetStringSliceOrDefault returns the value in comma separated []string format or the default value.
+func GetStringSliceOrDefault(key string, defaultValue []string) []string {
+	return defaultGetter.GetStringSliceOrDefault(key, defaultValue)
+}
+
+// GetStringSliceOrDefault returns the value in comma separated []string format or the default value according to the fallback policy.
+func (g *Getter) GetStringSliceOrDefault(key string, defaultValue []string) []string {
+	return getOrDefault(g, key, defaultValue, parseStringSlice)
+}
+
+// GetIntSlice returns the value in comma separated []int format of the environment variable named by the key.
+func GetIntSlice(key string) []int {
+	return defaultGetter.GetIntSlice(key)
+}
+
+// GetIntSlice returns the value in comma separated []int format of the variable named by the key.
+func (g *Getter) GetIntSlice(key string) []int {
+	return getOrDefault(g, key, nil, g.parseIntSlice)
+}
+
+// GetIntSliceOrDefault returns the value in comma separated []int format or the default value.
+func GetIntSliceOrDefault(key string, defaultValue []int) []int {
+	return defaultGetter.GetIntSliceOrDefault(key, defaultValue)
+}
+
+// GetIntSliceOrDefault returns the value in comma separated []int format or the default value according to the fallback policy.
+func (g *Getter) GetIntSliceOrDefault(key string, defaultValue []int) []int {
+	return getOrDefault(g, key, defaultValue, g.parseIntSlice)
+}
+
+// GetStringMap returns the value in map[string]string format of the environment variable named by the key.
+func GetStringMap(key string) map[string]string {
+	return defaultGetter.GetStringMap(key)
+}
+
+// GetStringMap returns the value in map[string]string format of the variable named by the key.
+func (g *Getter) GetStringMap(key string) map[string]string {
+	return getOrDefault(g, key, nil, parseStringMap)
+}
+
+// GetStringMapOrDefault returns the value in map[string]string format or the default value.
+func GetStringMapOrDefault(key string, defaultValue map[string]string) map[string]string {
+	return defaultGetter.GetStringMapOrDefault(key, defaultValue)
+}
+
+// GetStringMapOrDefault returns the value in map[string]string format or the default value according to the fallback policy.
+func (g *Getter) GetStringMapOrDefault(key string, defaultValue map[string]string) map[string]string {
+	return getOrDefault(g, key, defaultValue, parseStringMap)
+}
+
+// GetBytes returns the byte size of the environment variable named by the key, e.g. 10MiB or 1.5GB.
+func GetBytes(key string) int64 {
+	return defaultGetter.GetBytes(key)
+}
+
+// GetBytes returns the byte size of the variable named by the key, e.g. 10MiB or 1.5GB.
+func (g *Getter) GetBytes(key string) int64 {
+	return getOrDefault(g, key, 0, parseBytesInt64)
+}
+
+// GetBytesOrDefault returns the byte size or the default value.
+func GetBytesOrDefault(key string, defaultValue int64) int64 {
+	return defaultGetter.GetBytesOrDefault(key, defaultValue)
+}
+
+// GetBytesOrDefault returns the byte size or the default value according to the fallback policy.
+func (g *Getter) GetBytesOrDefault(key string, defaultValue int64) int64 {
+	return getOrDefault(g, key, defaultValue, parseBytesInt64)
+}
+
+// GetPercent returns the ratio of the environment variable named by the key, e.g. 0.75 for 75%.
+func GetPercent(key string) float64 {
+	return defaultGetter.GetPercent(key)
+}
+
+// GetPercent returns the ratio of the variable named by the key, e.g. 0.75 for 75%.
+func (g *Getter) GetPercent(key string) float64 {
+	return getOrDefault(g, key, 0, parsePercent)
+}
+
+// GetPercentOrDefault returns the ratio or the default value.
+func GetPercentOrDefault(key string, defaultValue float64) float64 {
+	return defaultGetter.GetPercentOrDefault(key, defaultValue)
+}
+
+// GetPercentOrDefault returns the ratio or the default value according to the fallback policy.
+func (g *Getter) GetPercentOrDefault(key string, defaultValue float64) float64 {
+	return getOrDefault(g, key, defaultValue, parsePercent)
 }
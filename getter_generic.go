@@ -0,0 +1,105 @@
+package dotenv
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Lookup returns the value of the environment variable named by the key converted to T,
+// and reports whether the variable is set.
+// T can be any type supported by LoadStruct, including durations, slices and encoding.TextUnmarshaler.
+// The error is a *FieldError matching ErrParse or ErrUnsupportedType.
+func Lookup[T any](key string) (T, bool, error) {
+	return LookupFrom[T](OSLookuper{}, key)
+}
+
+// LookupFrom is Lookup reading the variable from the given Lookuper.
+func LookupFrom[T any](lookuper Lookuper, key string) (T, bool, error) {
+	return LookupWithOptions[T](key, LookupOptions{Lookuper: lookuper})
+}
+
+// LookupOptions provides configuration options for LookupWithOptions
+type LookupOptions struct {
+	// Lookuper reads the variable, the process environment when nil
+	Lookuper Lookuper
+
+	// Bools lists the accepted boolean spellings, DefaultBoolVocabulary when zero
+	Bools BoolVocabulary
+}
+
+// LookupWithOptions is Lookup with additional options
+func LookupWithOptions[T any](key string, opts LookupOptions) (T, bool, error) {
+	var result T
+
+	if opts.Lookuper == nil {
+		opts.Lookuper = OSLookuper{}
+	}
+
+	raw, exists := opts.Lookuper.Lookup(key)
+	if !exists {
+		return result, false, nil
+	}
+
+	if err := convert(&result, key, raw, opts.Bools); err != nil {
+		var zero T
+		return zero, true, err
+	}
+
+	return result, true, nil
+}
+
+// Get returns the value of the environment variable named by the key converted to T,
+// or the zero value of T if it is not set or invalid.
+func Get[T any](key string) T {
+	result, _, _ := Lookup[T](key)
+	return result
+}
+
+// GetOr returns the value of the environment variable named by the key converted to T,
+// or the default value if it is not set, empty or invalid.
+func GetOr[T any](key string, defaultValue T) T {
+	return GetOrFrom(OSLookuper{}, key, defaultValue)
+}
+
+// GetOrFrom is GetOr reading the variable from the given Lookuper.
+func GetOrFrom[T any](lookuper Lookuper, key string, defaultValue T) T {
+	// An empty value falls back to the default rather than being converted
+	if raw, exists := lookuper.Lookup(key); !exists || raw == "" {
+		return defaultValue
+	}
+
+	result, _, err := LookupFrom[T](lookuper, key)
+	if err != nil {
+		return defaultValue
+	}
+	return result
+}
+
+// MustGet returns the value of the environment variable named by the key converted to T,
+// and panics if it is not set or invalid.
+func MustGet[T any](key string) T {
+	result, exists, err := Lookup[T](key)
+	if err != nil {
+		panic(err)
+	}
+	if !exists {
+		panic(&FieldError{Key: key, Kind: ErrRequired, Err: fmt.Errorf("required environment variable %s is not set", key)})
+	}
+	return result
+}
+
+// convert parses raw into target with the conversion rules of the struct loader.
+func convert(target interface{}, key, raw string, bools BoolVocabulary) error {
+	value := reflect.ValueOf(target).Elem()
+
+	if err := setValue(value, reflect.StructField{Name: key}, raw, bools); err != nil {
+		kind := ErrParse
+		if errors.Is(err, ErrUnsupportedType) {
+			kind = ErrUnsupportedType
+		}
+		return &FieldError{Key: key, Value: raw, Kind: kind, Err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package dotenv
+
+import (
+	"errors"
+	"net"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLookupGeneric(t *testing.T) {
+	_ = os.Setenv("GENERIC_PORT", "8080")
+	_ = os.Setenv("GENERIC_BAD_PORT", "80x")
+	_ = os.Setenv("GENERIC_TIMEOUT", "1m30s")
+	_ = os.Setenv("GENERIC_HOSTS", "a.local,b.local")
+	_ = os.Setenv("GENERIC_IP", "10.0.0.1")
+
+	defer func() {
+		for _, key := range []string{"GENERIC_PORT", "GENERIC_BAD_PORT", "GENERIC_TIMEOUT", "GENERIC_HOSTS", "GENERIC_IP"} {
+			_ = os.Unsetenv(key)
+		}
+	}()
+
+	port, exists, err := Lookup[int]("GENERIC_PORT")
+	if port != 8080 || !exists || err != nil {
+		t.Errorf("Lookup[int](GENERIC_PORT) = %d, %t, %v", port, exists, err)
+	}
+
+	port, exists, err = Lookup[int]("GENERIC_BAD_PORT")
+	if port != 0 || !exists || !errors.Is(err, ErrParse) {
+		t.Errorf("Lookup[int](GENERIC_BAD_PORT) = %d, %t, %v", port, exists, err)
+	}
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Key != "GENERIC_BAD_PORT" || fieldErr.Value != "80x" {
+		t.Errorf("Expected a *FieldError for GENERIC_BAD_PORT, got: %+v", fieldErr)
+	}
+
+	_, exists, err = Lookup[int]("GENERIC_MISSING")
+	if exists || err != nil {
+		t.Errorf("Lookup[int](GENERIC_MISSING) = %t, %v", exists, err)
+	}
+
+	if timeout := Get[time.Duration]("GENERIC_TIMEOUT"); timeout != 90*time.Second {
+		t.Errorf("Get[time.Duration]() = %s", timeout)
+	}
+
+	if hosts := Get[[]string]("GENERIC_HOSTS"); !reflect.DeepEqual(hosts, []string{"a.local", "b.local"}) {
+		t.Errorf("Get[[]string]() = %v", hosts)
+	}
+
+	if ip := Get[net.IP]("GENERIC_IP"); !ip.Equal(net.ParseIP("10.0.0.1")) {
+		t.Errorf("Get[net.IP]() = %s", ip)
+	}
+
+	if ptr := Get[*int]("GENERIC_PORT"); ptr == nil || *ptr != 8080 {
+		t.Errorf("Get[*int]() = %v", ptr)
+	}
+
+	_, _, err = Lookup[chan int]("GENERIC_PORT")
+	if !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Expected ErrUnsupportedType, got: %v", err)
+	}
+}
+
+func TestGetOrGeneric(t *testing.T) {
+	_ = os.Setenv("GENERIC_OR_VALID", "true")
+	_ = os.Setenv("GENERIC_OR_INVALID", "maybe")
+	_ = os.Setenv("GENERIC_OR_EMPTY", "")
+
+	defer func() {
+		_ = os.Unsetenv("GENERIC_OR_VALID")
+		_ = os.Unsetenv("GENERIC_OR_INVALID")
+		_ = os.Unsetenv("GENERIC_OR_EMPTY")
+	}()
+
+	if GetOr("GENERIC_OR_VALID", false) != true {
+		t.Error("GetOr() should return true")
+	}
+
+	if GetOr("GENERIC_OR_INVALID", true) != true {
+		t.Error("GetOr() should return the default for an invalid value")
+	}
+
+	if GetOr("GENERIC_OR_EMPTY", "default") != "default" {
+		t.Error("GetOr() should return the default for an empty value")
+	}
+
+	if GetOr("GENERIC_OR_MISSING", 3.5) != 3.5 {
+		t.Error("GetOr() should return the default for a missing value")
+	}
+}
+
+func TestMustGetGeneric(t *testing.T) {
+	_ = os.Setenv("GENERIC_MUST", "42")
+	defer func() { _ = os.Unsetenv("GENERIC_MUST") }()
+
+	if MustGet[uint8]("GENERIC_MUST") != 42 {
+		t.Error("MustGet() should return 42")
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrRequired) {
+			t.Errorf("MustGet() should panic with ErrRequired, got: %v", err)
+		}
+	}()
+
+	MustGet[int]("GENERIC_MUST_MISSING")
+}
+
+func TestLookupFromGeneric(t *testing.T) {
+	t.Parallel()
+
+	lookuper := MapLookuper{"RATIO": "0.75"}
+
+	ratio, exists, err := LookupFrom[float64](lookuper, "RATIO")
+	if ratio != 0.75 || !exists || err != nil {
+		t.Errorf("LookupFrom[float64](RATIO) = %f, %t, %v", ratio, exists, err)
+	}
+}
+
+func TestLookupWithOptionsGeneric(t *testing.T) {
+	t.Parallel()
+
+	lookuper := MapLookuper{"DEBUG": "oui", "VERBOSE": "yes"}
+	opts := LookupOptions{Lookuper: lookuper, Bools: BoolVocabulary{True: []string{"oui"}, False: []string{"non"}}}
+
+	debug, exists, err := LookupWithOptions[bool]("DEBUG", opts)
+	if !debug || !exists || err != nil {
+		t.Errorf("LookupWithOptions[bool](DEBUG) = %t, %t, %v", debug, exists, err)
+	}
+
+	if _, _, err = LookupWithOptions[bool]("VERBOSE", opts); !errors.Is(err, ErrParse) {
+		t.Errorf("Expected ErrParse for a spelling outside the vocabulary, got: %v", err)
+	}
+
+	strict := LookupOptions{Lookuper: lookuper, Bools: BoolVocabulary{Strict: true}}
+	if _, _, err = LookupWithOptions[bool]("VERBOSE", strict); !errors.Is(err, ErrParse) {
+		t.Errorf("Expected ErrParse with Strict, got: %v", err)
+	}
+
+	if _, exists, err = LookupWithOptions[bool]("MISSING", opts); exists || err != nil {
+		t.Errorf("LookupWithOptions[bool](MISSING) = %t, %v", exists, err)
+	}
+}
+
+func TestGetOrFromGeneric(t *testing.T) {
+	t.Parallel()
+
+	lookuper := MapLookuper{"WORKERS": "8", "EMPTY": "", "BAD": "eight"}
+
+	tests := []struct {
+		key      string
+		expected int
+	}{
+		{"WORKERS", 8},
+		{"EMPTY", 4},
+		{"BAD", 4},
+		{"MISSING", 4},
+	}
+
+	for _, tt := range tests {
+		if got := GetOrFrom(lookuper, tt.key, 4); got != tt.expected {
+			t.Errorf("GetOrFrom(%s) = %d, want %d", tt.key, got, tt.expected)
+		}
+	}
+}
@@ -1,8 +1,13 @@
 package dotenv
 
 import (
+	"errors"
+	"net"
 	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestGetString(t *testing.T) {
@@ -180,3 +185,241 @@ func TestGetFloat64WithDefaultWithValidValue(t *testing.T) {
 		t.Error("GetFloat64() should return 1.1")
 	}
 }
+
+func TestGetStringFromFile(t *testing.T) {
+	location := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(location, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = os.Unsetenv("TEST_SECRET")
+	_ = os.Setenv("TEST_SECRET_FILE", location)
+	defer func() { _ = os.Unsetenv("TEST_SECRET_FILE") }()
+
+	if GetStringFromFile("TEST_SECRET") != "s3cret" {
+		t.Error("GetStringFromFile() should return \"s3cret\"")
+	}
+
+	_ = os.Setenv("TEST_SECRET", "value")
+	defer func() { _ = os.Unsetenv("TEST_SECRET") }()
+
+	if GetStringFromFile("TEST_SECRET") != "" {
+		t.Error("GetStringFromFile() should return \"\" when both variables are set")
+	}
+}
+
+func TestLookupStringFromFile(t *testing.T) {
+	location := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(location, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	getter := NewGetter(MapLookuper{
+		"FROM_FILE_FILE":    location,
+		"CONFLICT":          "value",
+		"CONFLICT_FILE":     location,
+		"MISSING_FILE_FILE": filepath.Join(t.TempDir(), "missing"),
+		"PLAIN":             "plain",
+	})
+
+	if value, exists, err := getter.LookupStringFromFile("FROM_FILE"); value != "s3cret" || !exists || err != nil {
+		t.Errorf("LookupStringFromFile(FROM_FILE) = %q, %t, %v", value, exists, err)
+	}
+
+	if value, exists, err := getter.LookupStringFromFile("PLAIN"); value != "plain" || !exists || err != nil {
+		t.Errorf("LookupStringFromFile(PLAIN) = %q, %t, %v", value, exists, err)
+	}
+
+	if _, exists, err := getter.LookupStringFromFile("UNSET"); exists || err != nil {
+		t.Errorf("LookupStringFromFile(UNSET) = %t, %v", exists, err)
+	}
+
+	if _, _, err := getter.LookupStringFromFile("CONFLICT"); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected ErrConflict, got: %v", err)
+	}
+
+	if _, _, err := getter.LookupStringFromFile("MISSING_FILE"); !errors.Is(err, ErrParse) || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected ErrParse and os.ErrNotExist, got: %v", err)
+	}
+}
+
+func TestGetterFallbackPolicies(t *testing.T) {
+	lookuper := MapLookuper{"EMPTY": "", "INVALID": "80x", "VALID": "8080"}
+
+	tests := []struct {
+		policy  FallbackPolicy
+		key     string
+		want    int
+		invalid bool
+	}{
+		{FallbackOnInvalid, "MISSING", 1, false},
+		{FallbackOnInvalid, "EMPTY", 1, false},
+		{FallbackOnInvalid, "INVALID", 1, true},
+		{FallbackOnInvalid, "VALID", 8080, false},
+		{FallbackOnEmpty, "MISSING", 1, false},
+		{FallbackOnEmpty, "EMPTY", 1, false},
+		{FallbackOnEmpty, "INVALID", 0, true},
+		{FallbackOnUnset, "MISSING", 1, false},
+		{FallbackOnUnset, "EMPTY", 0, true},
+		{FallbackOnUnset, "INVALID", 0, true},
+		{FallbackOnUnset, "VALID", 8080, false},
+	}
+
+	for _, tt := range tests {
+		var reported []string
+		getter := NewGetterWithOptions(lookuper, GetterOptions{
+			Policy: tt.policy,
+			OnInvalid: func(key, value string, err error) {
+				reported = append(reported, key)
+			},
+		})
+
+		if got := getter.GetIntOrDefault(tt.key, 1); got != tt.want {
+			t.Errorf("policy %d: GetIntOrDefault(%q) = %d, want %d", tt.policy, tt.key, got, tt.want)
+		}
+
+		if (len(reported) == 1) != tt.invalid {
+			t.Errorf("policy %d: GetIntOrDefault(%q) reported %v", tt.policy, tt.key, reported)
+		}
+	}
+}
+
+func TestGetterFallbackOnUnsetKeepsEmptyString(t *testing.T) {
+	getter := NewGetterWithOptions(MapLookuper{"EMPTY": ""}, GetterOptions{Policy: FallbackOnUnset})
+
+	if getter.GetStringOrDefault("EMPTY", "default") != "" {
+		t.Error("GetStringOrDefault() should return \"\"")
+	}
+
+	if getter.GetStringOrDefault("MISSING", "default") != "default" {
+		t.Error("GetStringOrDefault() should return \"default\"")
+	}
+}
+
+func TestGetterFallbackOnUnsetBlankCollections(t *testing.T) {
+	var reported []string
+	getter := NewGetterWithOptions(MapLookuper{"EMPTY": "", "BLANK": "  "}, GetterOptions{
+		Policy:    FallbackOnUnset,
+		OnInvalid: func(key, _ string, _ error) { reported = append(reported, key) },
+	})
+
+	for _, key := range []string{"EMPTY", "BLANK"} {
+		if got := getter.GetStringSliceOrDefault(key, []string{"default"}); got == nil || len(got) != 0 {
+			t.Errorf("GetStringSliceOrDefault(%s) = %q, want an empty slice", key, got)
+		}
+		if got := getter.GetIntSliceOrDefault(key, []int{1}); got == nil || len(got) != 0 {
+			t.Errorf("GetIntSliceOrDefault(%s) = %v, want an empty slice", key, got)
+		}
+		if got := getter.GetStringMapOrDefault(key, map[string]string{"a": "b"}); got == nil || len(got) != 0 {
+			t.Errorf("GetStringMapOrDefault(%s) = %v, want an empty map", key, got)
+		}
+	}
+
+	if len(reported) != 0 {
+		t.Errorf("Blank values should not be reported as invalid, got: %v", reported)
+	}
+}
+
+func TestGetOrDefaultConsistency(t *testing.T) {
+	err := os.Setenv("TEST", "invalid")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if GetIntOrDefault("TEST", 1) != 1 || GetInt64OrDefault("TEST", 1) != 1 || GetUintOrDefault("TEST", 1) != 1 {
+		t.Error("integer OrDefault getters should fall back on invalid values")
+	}
+
+	if GetFloat32OrDefault("TEST", 1) != 1 || GetFloat64OrDefault("TEST", 1) != 1 || GetBoolOrDefault("TEST", true) != true {
+		t.Error("float and bool OrDefault getters should fall back on invalid values")
+	}
+}
+
+func TestGetterWidthsAndTypes(t *testing.T) {
+	getter := NewGetter(MapLookuper{
+		"INT8":     "-128",
+		"INT16":    "32767",
+		"INT32":    "-2147483648",
+		"UINT8":    "255",
+		"UINT16":   "65535",
+		"UINT32":   "4294967295",
+		"UINT64":   "18446744073709551615",
+		"DURATION": "1m30s",
+		"URL":      "https://example.com/path?q=1",
+		"IP":       "10.0.0.1",
+		"HOSTS":    "a.local, b.local",
+		"PORTS":    "80, 443",
+		"LABELS":   "region=eu, tier=gold",
+	})
+
+	if getter.GetInt8("INT8") != -128 || getter.GetInt16("INT16") != 32767 || getter.GetInt32("INT32") != -2147483648 {
+		t.Error("signed integer getters returned unexpected values")
+	}
+
+	if getter.GetUint8("UINT8") != 255 || getter.GetUint16("UINT16") != 65535 ||
+		getter.GetUint32("UINT32") != 4294967295 || getter.GetUint64("UINT64") != 18446744073709551615 {
+		t.Error("unsigned integer getters returned unexpected values")
+	}
+
+	if getter.GetDuration("DURATION") != 90*time.Second {
+		t.Errorf("GetDuration() = %s, want 1m30s", getter.GetDuration("DURATION"))
+	}
+
+	if u := getter.GetURL("URL"); u == nil || u.Host != "example.com" || u.Path != "/path" {
+		t.Errorf("GetURL() = %v, want https://example.com/path?q=1", u)
+	}
+
+	if !getter.GetIP("IP").Equal(net.ParseIP("10.0.0.1")) {
+		t.Errorf("GetIP() = %s, want 10.0.0.1", getter.GetIP("IP"))
+	}
+
+	if got := getter.GetStringSlice("HOSTS"); !reflect.DeepEqual(got, []string{"a.local", "b.local"}) {
+		t.Errorf("GetStringSlice() = %v", got)
+	}
+
+	if got := getter.GetIntSlice("PORTS"); !reflect.DeepEqual(got, []int{80, 443}) {
+		t.Errorf("GetIntSlice() = %v", got)
+	}
+
+	if got := getter.GetStringMap("LABELS"); !reflect.DeepEqual(got, map[string]string{"region": "eu", "tier": "gold"}) {
+		t.Errorf("GetStringMap() = %v", got)
+	}
+}
+
+func TestGetterWidthsOrDefault(t *testing.T) {
+	getter := NewGetter(MapLookuper{
+		"OVERFLOW": "300",
+		"NEGATIVE": "-1",
+		"INVALID":  "invalid",
+		"PORTS":    "80,http",
+		"LABELS":   "region",
+	})
+
+	if getter.GetInt8OrDefault("OVERFLOW", 1) != 1 || getter.GetUint8OrDefault("OVERFLOW", 1) != 1 {
+		t.Error("8-bit getters should fall back on out of range values")
+	}
+
+	if getter.GetUint64OrDefault("NEGATIVE", 1) != 1 || getter.GetInt16OrDefault("MISSING", 1) != 1 {
+		t.Error("integer getters should fall back on invalid or missing values")
+	}
+
+	if getter.GetDurationOrDefault("INVALID", time.Second) != time.Second {
+		t.Error("GetDurationOrDefault() should fall back on invalid values")
+	}
+
+	if getter.GetIPOrDefault("INVALID", net.IPv4zero) == nil || getter.GetIP("INVALID") != nil {
+		t.Error("GetIP() should reject invalid addresses")
+	}
+
+	if got := getter.GetIntSliceOrDefault("PORTS", []int{8080}); !reflect.DeepEqual(got, []int{8080}) {
+		t.Errorf("GetIntSliceOrDefault() = %v, want [8080]", got)
+	}
+
+	if got := getter.GetStringMapOrDefault("LABELS", map[string]string{}); got == nil || len(got) != 0 {
+		t.Errorf("GetStringMapOrDefault() = %v, want empty map", got)
+	}
+
+	if got := getter.GetStringSliceOrDefault("MISSING", []string{"x"}); !reflect.DeepEqual(got, []string{"x"}) {
+		t.Errorf("GetStringSliceOrDefault() = %v, want [x]", got)
+	}
+}
@@ -1,52 +1,147 @@
 package dotenv
 
 import (
+	"encoding"
+	"errors"
 	"fmt"
-	"os"
+	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultSeparator splits the values of slice and map fields without separator tag.
+	defaultSeparator = ","
+	// defaultKeyValueSeparator splits the key and the value of each map entry, e.g. "region=eu".
+	defaultKeyValueSeparator = "="
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	urlType             = reflect.TypeOf(url.URL{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
 )
 
 // Validator defines the interface for format validators
 type Validator func(value reflect.Value) error
 
+// StructValidator validates a whole struct once all its fields are loaded,
+// for rules involving several fields. It receives the pointer given to LoadStructWithOptions.
+type StructValidator func(data interface{}) error
+
+// validatable is implemented by structs checking their own consistency once loaded.
+type validatable interface {
+	Validate() error
+}
+
 // LoadOptions provides configuration options for LoadStructWithOptions
 type LoadOptions struct {
 	// Validators is a map of validator name to validator implementation
 	// When validator tag is present, the corresponding validator will be used
+	// Custom validators take precedence over the built-in ones with the same name
 	Validators map[string]Validator
-}
 
-func LoadStruct(data interface{}) error {
-	dataType := reflect.TypeOf(data)
-	dataValue := reflect.ValueOf(data)
+	// Prefix is prepended to every env key of the struct, before any envPrefix tag
+	Prefix string
 
-	if dataType.Kind() != reflect.Ptr || dataValue.IsNil() {
-		return fmt.Errorf("data must be a pointer to a struct")
-	}
+	// FileSuffix enables the file convention for every field: when KEY+FileSuffix is set,
+	// e.g. DB_PASSWORD_FILE, the value of KEY is read from the file it points to.
+	// Fields tagged file:"true" use it too, with "_FILE" when it is empty.
+	FileSuffix string
 
-	dataType = dataType.Elem()
-	dataValue = dataValue.Elem()
+	// Lookuper is the source of the variables, the process environment when nil
+	Lookuper Lookuper
 
-	return parseFields(dataType, dataValue, LoadOptions{})
+	// Report, when not nil, receives where the value of every field comes from
+	Report *Report
+
+	// Expand enables variable expansion for every field, as the expand:"true" tag does
+	Expand bool
+
+	// StructValidators are called in order with the loaded struct,
+	// after the Validate methods of the struct and its nested structs
+	StructValidators []StructValidator
+
+	// Bools is the vocabulary of bool fields, DefaultBoolVocabulary when empty
+	Bools BoolVocabulary
+}
+
+func LoadStruct(data interface{}) error {
+	return LoadStructWithOptions(data, LoadOptions{})
 }
 
 // LoadStructWithOptions loads environment variables into a struct with additional options
+// Every field is processed, and all failures are returned together in a *LoadError.
 func LoadStructWithOptions(data interface{}, opts LoadOptions) error {
-	dataType := reflect.TypeOf(data)
-	dataValue := reflect.ValueOf(data)
+	if err := checkStructPointer(data); err != nil {
+		return err
+	}
+
+	dataType := reflect.TypeOf(data).Elem()
+	dataValue := reflect.ValueOf(data).Elem()
+
+	if opts.Lookuper == nil {
+		opts.Lookuper = OSLookuper{}
+	}
+
+	l := &loader{opts: opts, values: map[string]string{}, declared: map[string]reflect.StructField{}, resolving: map[string]bool{}}
+	l.declareFields(dataType, opts.Prefix)
+	l.parseFields(dataType, dataValue, "", opts.Prefix)
+	l.checkConditions()
+	l.validateStruct(dataValue, "")
+
+	for _, validator := range opts.StructValidators {
+		if err := validator(data); err != nil {
+			l.fail(ErrValidation, "", "", "", fmt.Errorf("struct validation failed: %w", err))
+		}
+	}
 
-	if dataType.Kind() != reflect.Ptr || dataValue.IsNil() {
-		return fmt.Errorf("data must be a pointer to a struct")
+	if len(l.errs) > 0 {
+		return &LoadError{Errors: l.errs}
 	}
 
-	dataType = dataType.Elem()
-	dataValue = dataValue.Elem()
+	return nil
+}
 
-	return parseFields(dataType, dataValue, opts)
+// loader holds the state of a single LoadStructWithOptions call.
+type loader struct {
+	opts LoadOptions
+	errs []*FieldError
+	// values are the resolved values of the loaded fields, by env key
+	values map[string]string
+	// declared are the fields of the struct by env key, to resolve the references
+	// of variable expansion to fields that are not loaded yet
+	declared map[string]reflect.StructField
+	// resolving are the env keys being expanded, to detect circular references
+	resolving map[string]bool
+	// cycle is the key of the last circular reference met while expanding
+	cycle string
+	// conditions are the conditionally required fields left unset
+	conditions []fieldCondition
 }
 
-func parseFields(dataType reflect.Type, dataValue reflect.Value, opts LoadOptions) error {
+// fail records a field error and lets the loading continue.
+func (l *loader) fail(kind error, path, key, value string, err error) {
+	l.errs = append(l.errs, &FieldError{Field: path, Key: key, Value: value, Kind: kind, Err: err})
+}
+
+// failValue records a field error about a value, which is redacted for secret fields.
+func (l *loader) failValue(kind error, path, key, value string, secret bool, err error) {
+	if secret {
+		err = redactError(err, value)
+		value = Redact(value)
+	}
+	l.errs = append(l.errs, &FieldError{Field: path, Key: key, Value: value, Secret: secret, Kind: kind, Err: err})
+}
+
+// parseFields loads the fields of a struct, prefixing every env key with prefix.
+// path is the Go path of the struct, used to report errors.
+// It reports whether at least one field has been loaded.
+func (l *loader) parseFields(dataType reflect.Type, dataValue reflect.Value, path, prefix string) bool {
+	loaded := false
+
 	for i := 0; i < dataType.NumField(); i++ {
 		field := dataType.Field(i)
 		value := dataValue.Field(i)
@@ -56,9 +151,29 @@ func parseFields(dataType reflect.Type, dataValue reflect.Value, opts LoadOption
 			continue
 		}
 
-		if value.Kind() == reflect.Struct {
-			if err := parseFields(field.Type, value, opts); err != nil {
-				return err
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+
+		if isNestedStruct(field.Type) {
+			if l.parseFields(field.Type, value, fieldPath, prefix+field.Tag.Get("envPrefix")) {
+				loaded = true
+			}
+			l.validateStruct(value, fieldPath)
+			continue
+		}
+
+		// Pointer to struct is only allocated when at least one of its fields is loaded
+		if value.Kind() == reflect.Ptr && isNestedStruct(field.Type.Elem()) {
+			nested := value
+			if value.IsNil() {
+				nested = reflect.New(field.Type.Elem())
+			}
+			if l.parseFields(field.Type.Elem(), nested.Elem(), fieldPath, prefix+field.Tag.Get("envPrefix")) {
+				value.Set(nested)
+				loaded = true
+				l.validateStruct(nested.Elem(), fieldPath)
 			}
 			continue
 		}
@@ -67,66 +182,360 @@ func parseFields(dataType reflect.Type, dataValue reflect.Value, opts LoadOption
 		if envTag == "" {
 			continue
 		}
+		envTag = prefix + envTag
+		secret := field.Tag.Get("secret") == "true"
 
 		isRequired := false
 		if reqTag := field.Tag.Get("required"); reqTag == "true" {
 			isRequired = true
 		}
 
-		envValue, found := os.LookupEnv(envTag)
+		envValue, found := l.opts.Lookuper.Lookup(envTag)
+		source := Source{Field: fieldPath, Key: envTag, Origin: OriginEnv, Secret: secret}
+		if found {
+			if origin, isFromFile := lookupOrigin(l.opts.Lookuper, envTag); isFromFile {
+				source.Origin, source.File, source.Line = OriginFile, origin.file, origin.line
+				source.References = append([]string(nil), origin.references...)
+			}
+		}
+
+		if suffix := l.fileSuffix(field); suffix != "" {
+			fileKey := envTag + suffix
+			if location, isSet := l.opts.Lookuper.Lookup(fileKey); isSet {
+				if found {
+					l.fail(ErrConflict, fieldPath, envTag, "", fmt.Errorf("both %s and %s are set for field %s", envTag, fileKey, field.Name))
+					continue
+				}
+
+				fileValue, err := readValueFile(location)
+				if err != nil {
+					l.fail(ErrParse, fieldPath, fileKey, location, fmt.Errorf("failed to read file for field %s: %w", field.Name, err))
+					continue
+				}
+				envValue, found = fileValue, true
+				source = Source{Field: fieldPath, Key: envTag, Origin: OriginFile, File: location, Secret: secret}
+			}
+		}
+
 		if !found {
 			// Use Lookup to distinguish between missing tag and empty tag value
 			defaultTag, hasDefault := field.Tag.Lookup("default")
 			if !hasDefault {
 				if isRequired {
-					return fmt.Errorf("required environment variable %s is not set", envTag)
+					l.fail(ErrRequired, fieldPath, envTag, "", fmt.Errorf("required environment variable %s is not set", envTag))
+				} else {
+					l.deferConditions(field, fieldPath, envTag, prefix)
 				}
+				source.Origin = OriginUnset
+				l.report(source, "")
 				continue
 			}
 			envValue = defaultTag
+			source.Origin = OriginDefault
 		}
 
-		switch value.Kind() {
-		case reflect.String:
-			value.SetString(envValue)
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			intVal, err := strconv.ParseInt(envValue, 10, 64)
-			if err != nil {
-				return fmt.Errorf("failed to parse int field %s: %s", field.Name, err)
+		// Expand ${VAR}, ${VAR:-default} and $VAR, resolving the referenced fields first
+		if l.expands(field) {
+			l.resolving[envTag] = true
+			l.cycle = ""
+			envValue = expand(envValue, func(key string) (string, bool) {
+				source.References = append(source.References, key)
+				return l.lookup(key)
+			})
+			delete(l.resolving, envTag)
+
+			if l.cycle != "" {
+				l.fail(ErrParse, fieldPath, envTag, "", fmt.Errorf("circular reference to %s while expanding field %s", l.cycle, field.Name))
+				continue
 			}
-			value.SetInt(intVal)
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			uintVal, err := strconv.ParseUint(envValue, 10, 64)
-			if err != nil {
-				return fmt.Errorf("failed to parse uint field %s: %s", field.Name, err)
+		}
+		l.values[envTag] = envValue
+		l.report(source, envValue)
+
+		if err := setValue(value, field, envValue, l.opts.Bools); err != nil {
+			kind := ErrParse
+			if errors.Is(err, ErrUnsupportedType) {
+				kind = ErrUnsupportedType
 			}
-			value.SetUint(uintVal)
-		case reflect.Bool:
-			boolVal, err := strconv.ParseBool(envValue)
-			if err != nil {
-				return fmt.Errorf("failed to parse bool field %s: %s", field.Name, err)
+			l.failValue(kind, fieldPath, envTag, envValue, secret, err)
+			continue
+		}
+		loaded = true
+
+		// Validate format if validator is provided
+		if validatorTag := field.Tag.Get("validator"); validatorTag != "" {
+			if err := validate(reflect.Indirect(value), validatorTag, l.opts.Validators); err != nil {
+				l.failValue(ErrValidation, fieldPath, envTag, envValue, secret, fmt.Errorf("format validation failed for field %s: %w", field.Name, err))
 			}
-			value.SetBool(boolVal)
-		case reflect.Float32, reflect.Float64:
-			floatVal, err := strconv.ParseFloat(envValue, 64)
-			if err != nil {
-				return fmt.Errorf("failed to parse float field %s: %s", field.Name, err)
+		}
+	}
+
+	return loaded
+}
+
+// report adds the source of a field to LoadOptions.Report, if any.
+func (l *loader) report(source Source, value string) {
+	if l.opts.Report == nil {
+		return
+	}
+
+	source.Value = value
+	if source.Secret && source.Origin != OriginUnset {
+		source.Value = Redact(value)
+	}
+	l.opts.Report.Sources = append(l.opts.Report.Sources, source)
+}
+
+// lookup returns the resolved value of another field, defaults included,
+// or the variable from the Lookuper when no field is loaded from key.
+func (l *loader) lookup(key string) (string, bool) {
+	if value, exists := l.values[key]; exists {
+		return value, true
+	}
+	if field, isDeclared := l.declared[key]; isDeclared {
+		return l.resolve(field, key)
+	}
+	return l.opts.Lookuper.Lookup(key)
+}
+
+// resolve returns the value of a field that is not loaded yet, from the Lookuper,
+// its file or its default, expanded when the field expands. Errors are left to the loading of the field.
+func (l *loader) resolve(field reflect.StructField, key string) (string, bool) {
+	if l.resolving[key] {
+		l.cycle = key
+		return "", false
+	}
+
+	value, found := l.opts.Lookuper.Lookup(key)
+	if suffix := l.fileSuffix(field); suffix != "" && !found {
+		if location, isSet := l.opts.Lookuper.Lookup(key + suffix); isSet {
+			if fileValue, err := readValueFile(location); err == nil {
+				value, found = fileValue, true
 			}
-			value.SetFloat(floatVal)
-		default:
-			return fmt.Errorf("unsupported type for field %s", field.Name)
 		}
+	}
+	if !found {
+		value, found = field.Tag.Lookup("default")
+	}
 
-		// Validate format if validator is provided
-		validatorTag := field.Tag.Get("validator")
-		if validatorTag != "" && opts.Validators != nil {
-			if validator, exists := opts.Validators[validatorTag]; exists {
-				if err := validator(value); err != nil {
-					return fmt.Errorf("format validation failed for field %s: %s", field.Name, err)
-				}
+	if found && l.expands(field) {
+		l.resolving[key] = true
+		value = expand(value, l.lookup)
+		delete(l.resolving, key)
+	}
+
+	return value, found
+}
+
+// expands reports whether the value of field is expanded.
+func (l *loader) expands(field reflect.StructField) bool {
+	return l.opts.Expand || field.Tag.Get("expand") == "true"
+}
+
+// declareFields records the fields of a struct by env key, as parseFields walks them.
+func (l *loader) declareFields(dataType reflect.Type, prefix string) {
+	for i := 0; i < dataType.NumField(); i++ {
+		field := dataType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		switch {
+		case isNestedStruct(field.Type):
+			l.declareFields(field.Type, prefix+field.Tag.Get("envPrefix"))
+		case field.Type.Kind() == reflect.Ptr && isNestedStruct(field.Type.Elem()):
+			l.declareFields(field.Type.Elem(), prefix+field.Tag.Get("envPrefix"))
+		case field.Tag.Get("env") != "":
+			l.declared[prefix+field.Tag.Get("env")] = field
+		}
+	}
+}
+
+// checkStructPointer returns an error unless data is a non-nil pointer to a struct.
+func checkStructPointer(data interface{}) error {
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("data must be a non-nil pointer to a struct")
+	}
+	return nil
+}
+
+// isNestedStruct reports whether a field of type t is loaded as a nested struct,
+// rather than from a single variable like time.Time.
+func isNestedStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != urlType && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// fileSuffix returns the suffix of the key holding the file path of the field, or "" when disabled.
+func (l *loader) fileSuffix(field reflect.StructField) string {
+	if l.opts.FileSuffix != "" {
+		return l.opts.FileSuffix
+	}
+	if field.Tag.Get("file") == "true" {
+		return defaultFileSuffix
+	}
+	return ""
+}
+
+// validateStruct calls the Validate method of the struct if it has one.
+func (l *loader) validateStruct(value reflect.Value, path string) {
+	v, ok := value.Addr().Interface().(validatable)
+	if !ok {
+		return
+	}
+
+	if err := v.Validate(); err != nil {
+		if path == "" {
+			l.fail(ErrValidation, path, "", "", fmt.Errorf("struct validation failed: %w", err))
+			return
+		}
+		l.fail(ErrValidation, path, "", "", fmt.Errorf("struct validation failed for field %s: %w", path, err))
+	}
+}
+
+// setValue converts envValue to the kind of value and stores it.
+// Pointer values are allocated when nil so that an unset variable keeps them nil.
+func setValue(value reflect.Value, field reflect.StructField, envValue string, bools BoolVocabulary) error {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			ptr := reflect.New(value.Type().Elem())
+			if err := setValue(ptr.Elem(), field, envValue, bools); err != nil {
+				return err
 			}
+			value.Set(ptr)
+			return nil
+		}
+		return setValue(value.Elem(), field, envValue, bools)
+	}
+
+	if value.CanAddr() {
+		if unmarshaler, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			if err := unmarshaler.UnmarshalText([]byte(envValue)); err != nil {
+				return fmt.Errorf("failed to parse %s field %s: %w", value.Type(), field.Name, err)
+			}
+			return nil
+		}
+	}
+
+	if value.Type() == durationType {
+		duration, err := time.ParseDuration(envValue)
+		if err != nil {
+			return fmt.Errorf("failed to parse duration field %s: %w", field.Name, err)
+		}
+		value.SetInt(int64(duration))
+		return nil
+	}
+
+	if value.Type() == urlType {
+		u, err := url.Parse(envValue)
+		if err != nil {
+			return fmt.Errorf("failed to parse url field %s: %w", field.Name, err)
+		}
+		value.Set(reflect.ValueOf(*u))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Slice:
+		return setSlice(value, field, envValue, bools)
+	case reflect.Map:
+		return setMap(value, field, envValue, bools)
+	case reflect.String:
+		value.SetString(envValue)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		intVal, err := parseIntField(field, envValue)
+		if errors.Is(err, strconv.ErrRange) || (err == nil && value.OverflowInt(intVal)) {
+			return fmt.Errorf("failed to parse int field %s: %w", field.Name, newRangeError(value.Type(), envValue))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse int field %s: %w", field.Name, err)
+		}
+		value.SetInt(intVal)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		uintVal, err := parseUintField(field, envValue)
+		if errors.Is(err, strconv.ErrRange) || (err == nil && value.OverflowUint(uintVal)) {
+			return fmt.Errorf("failed to parse uint field %s: %w", field.Name, newRangeError(value.Type(), envValue))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse uint field %s: %w", field.Name, err)
+		}
+		value.SetUint(uintVal)
+	case reflect.Bool:
+		boolVal, err := bools.Parse(envValue)
+		if err != nil {
+			return fmt.Errorf("failed to parse bool field %s: %w", field.Name, err)
+		}
+		value.SetBool(boolVal)
+	case reflect.Float32, reflect.Float64:
+		floatVal, err := parseFloatField(field, envValue)
+		if errors.Is(err, strconv.ErrRange) || (err == nil && value.OverflowFloat(floatVal)) {
+			return fmt.Errorf("failed to parse float field %s: %w", field.Name, newRangeError(value.Type(), envValue))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse float field %s: %w", field.Name, err)
+		}
+		value.SetFloat(floatVal)
+	default:
+		return fmt.Errorf("%w for field %s", ErrUnsupportedType, field.Name)
+	}
+
+	return nil
+}
+
+// setSlice splits envValue with the separator tag of the field, "," by default,
+// and converts every element. Elements are trimmed, and an empty value gives an empty slice.
+func setSlice(value reflect.Value, field reflect.StructField, envValue string, bools BoolVocabulary) error {
+	separator := field.Tag.Get("separator")
+	if separator == "" {
+		separator = defaultSeparator
+	}
+
+	var parts []string
+	if strings.TrimSpace(envValue) != "" {
+		parts = strings.Split(envValue, separator)
+	}
+
+	slice := reflect.MakeSlice(value.Type(), len(parts), len(parts))
+	for i, part := range parts {
+		if err := setValue(slice.Index(i), field, strings.TrimSpace(part), bools); err != nil {
+			return err
+		}
+	}
+
+	value.Set(slice)
+	return nil
+}
+
+// setMap fills a map from key=value pairs split by the separator tag, e.g. "region=eu,tier=gold".
+func setMap(value reflect.Value, field reflect.StructField, envValue string, bools BoolVocabulary) error {
+	separator := field.Tag.Get("separator")
+	if separator == "" {
+		separator = defaultSeparator
+	}
+
+	var parts []string
+	if strings.TrimSpace(envValue) != "" {
+		parts = strings.Split(envValue, separator)
+	}
+
+	result := reflect.MakeMapWithSize(value.Type(), len(parts))
+	for _, part := range parts {
+		rawKey, rawValue, found := strings.Cut(part, defaultKeyValueSeparator)
+		if !found {
+			return fmt.Errorf("failed to parse map field %s: missing %q in %q", field.Name, defaultKeyValueSeparator, strings.TrimSpace(part))
+		}
+
+		key := reflect.New(value.Type().Key()).Elem()
+		if err := setValue(key, field, strings.TrimSpace(rawKey), bools); err != nil {
+			return err
+		}
+		elem := reflect.New(value.Type().Elem()).Elem()
+		if err := setValue(elem, field, strings.TrimSpace(rawValue), bools); err != nil {
+			return err
 		}
+		result.SetMapIndex(key, elem)
 	}
 
+	value.Set(result)
 	return nil
 }
@@ -0,0 +1,101 @@
+package dotenv
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// fieldCondition is an unset field whose requirement depends on other variables.
+// It is evaluated once every field of the struct has been resolved.
+type fieldCondition struct {
+	path           string
+	key            string
+	prefix         string
+	requiredIf     string
+	requiredUnless string
+	requiredWith   string
+}
+
+// deferConditions records the required_if, required_unless and required_with tags of an unset field.
+func (l *loader) deferConditions(field reflect.StructField, path, key, prefix string) {
+	condition := fieldCondition{
+		path:           path,
+		key:            key,
+		prefix:         prefix,
+		requiredIf:     field.Tag.Get("required_if"),
+		requiredUnless: field.Tag.Get("required_unless"),
+		requiredWith:   field.Tag.Get("required_with"),
+	}
+
+	if condition.requiredIf == "" && condition.requiredUnless == "" && condition.requiredWith == "" {
+		return
+	}
+
+	l.conditions = append(l.conditions, condition)
+}
+
+// checkConditions reports the conditionally required fields whose condition holds.
+func (l *loader) checkConditions() {
+	for _, c := range l.conditions {
+		if c.requiredIf != "" {
+			if l.matchAll(c.requiredIf, c.prefix) {
+				l.fail(ErrRequired, c.path, c.key, "", fmt.Errorf(
+					"environment variable %s is required because %s", c.key, l.describe(c.requiredIf, c.prefix),
+				))
+				continue
+			}
+		}
+
+		if c.requiredUnless != "" {
+			if !l.matchAll(c.requiredUnless, c.prefix) {
+				l.fail(ErrRequired, c.path, c.key, "", fmt.Errorf(
+					"environment variable %s is required unless %s", c.key, l.describe(c.requiredUnless, c.prefix),
+				))
+				continue
+			}
+		}
+
+		if c.requiredWith != "" {
+			for _, other := range strings.Fields(c.requiredWith) {
+				if value, _ := l.lookup(c.prefix + other); value != "" {
+					l.fail(ErrRequired, c.path, c.key, "", fmt.Errorf(
+						"environment variable %s is required because %s is set", c.key, c.prefix+other,
+					))
+					break
+				}
+			}
+		}
+	}
+}
+
+// matchAll reports whether every KEY=value pair of the space separated conditions holds.
+// A pair without value, e.g. "KEY", holds when KEY is set to a non-empty value.
+func (l *loader) matchAll(conditions, prefix string) bool {
+	for _, condition := range strings.Fields(conditions) {
+		key, expected, hasValue := strings.Cut(condition, "=")
+		value, _ := l.lookup(prefix + key)
+
+		if hasValue && value != expected {
+			return false
+		}
+		if !hasValue && value == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// describe formats the conditions with their resolved keys for error messages.
+func (l *loader) describe(conditions, prefix string) string {
+	var parts []string
+	for _, condition := range strings.Fields(conditions) {
+		key, expected, hasValue := strings.Cut(condition, "=")
+		if hasValue {
+			parts = append(parts, fmt.Sprintf("%s=%s", prefix+key, expected))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s is set", prefix+key))
+		}
+	}
+	return strings.Join(parts, " and ")
+}
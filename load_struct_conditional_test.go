@@ -0,0 +1,113 @@
+package dotenv
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+type conditionalConfig struct {
+	Password string `env:"SMTP_PASSWORD" required_if:"MAIL_DRIVER=smtp"`
+	APIKey   string `env:"MAIL_API_KEY" required_unless:"MAIL_DRIVER=smtp"`
+	Driver   string `env:"MAIL_DRIVER" default:"smtp"`
+	CertFile string `env:"TLS_CERT" required_with:"TLS_KEY"`
+	KeyFile  string `env:"TLS_KEY"`
+}
+
+func TestLoadStruct_ConditionalRequired(t *testing.T) {
+	defer func() {
+		_ = os.Unsetenv("MAIL_DRIVER")
+		_ = os.Unsetenv("SMTP_PASSWORD")
+		_ = os.Unsetenv("MAIL_API_KEY")
+		_ = os.Unsetenv("TLS_KEY")
+	}()
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected []string
+	}{
+		{
+			name: "required_if holds with default of a later field",
+			env:  map[string]string{},
+			expected: []string{
+				"environment variable SMTP_PASSWORD is required because MAIL_DRIVER=smtp",
+			},
+		},
+		{
+			name: "required_if satisfied",
+			env:  map[string]string{"SMTP_PASSWORD": "secret"},
+		},
+		{
+			name: "required_unless holds",
+			env:  map[string]string{"MAIL_DRIVER": "ses"},
+			expected: []string{
+				"environment variable MAIL_API_KEY is required unless MAIL_DRIVER=smtp",
+			},
+		},
+		{
+			name: "required_with holds",
+			env:  map[string]string{"SMTP_PASSWORD": "secret", "TLS_KEY": "key.pem"},
+			expected: []string{
+				"environment variable TLS_CERT is required because TLS_KEY is set",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"MAIL_DRIVER", "SMTP_PASSWORD", "MAIL_API_KEY", "TLS_KEY"} {
+				_ = os.Unsetenv(key)
+			}
+			for key, value := range tt.env {
+				_ = os.Setenv(key, value)
+			}
+
+			err := LoadStruct(&conditionalConfig{})
+			if len(tt.expected) == 0 {
+				if err != nil {
+					t.Fatalf("Expected no error, got: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrRequired) {
+				t.Fatalf("Expected ErrRequired, got: %v", err)
+			}
+
+			var loadErr *LoadError
+			if !errors.As(err, &loadErr) || len(loadErr.Errors) != len(tt.expected) {
+				t.Fatalf("Expected %d errors, got: %v", len(tt.expected), err)
+			}
+
+			for i, want := range tt.expected {
+				if loadErr.Errors[i].Error() != want {
+					t.Errorf("Expected error %q, got %q", want, loadErr.Errors[i].Error())
+				}
+			}
+		})
+	}
+}
+
+func TestLoadStruct_ConditionalRequiredWithPrefix(t *testing.T) {
+	_ = os.Setenv("PRIMARY_MAIL_DRIVER", "smtp")
+	defer func() { _ = os.Unsetenv("PRIMARY_MAIL_DRIVER") }()
+
+	config := &struct {
+		Primary struct {
+			Password string `env:"SMTP_PASSWORD" required_if:"MAIL_DRIVER=smtp"`
+		} `envPrefix:"PRIMARY_"`
+		Secondary struct {
+			Password string `env:"SMTP_PASSWORD" required_if:"MAIL_DRIVER=smtp"`
+		} `envPrefix:"SECONDARY_"`
+	}{}
+
+	err := LoadStruct(config)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if err.Error() != "environment variable PRIMARY_SMTP_PASSWORD is required because PRIMARY_MAIL_DRIVER=smtp" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
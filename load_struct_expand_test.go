@@ -0,0 +1,106 @@
+package dotenv
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLoadStruct_ExpandTag(t *testing.T) {
+	_ = os.Setenv("EXPAND_HOME", "/home/user")
+	_ = os.Setenv("EXPAND_URL", "https://${EXPAND_HOST}:$EXPAND_PORT/api")
+
+	defer func() {
+		_ = os.Unsetenv("EXPAND_HOME")
+		_ = os.Unsetenv("EXPAND_URL")
+	}()
+
+	config := &struct {
+		Cache    string `env:"EXPAND_CACHE" default:"${EXPAND_HOME}/.cache" expand:"true"`
+		Host     string `env:"EXPAND_HOST" default:"localhost"`
+		Port     int    `env:"EXPAND_PORT" default:"${EXPAND_PORT_OVERRIDE:-8080}" expand:"true"`
+		URL      string `env:"EXPAND_URL" expand:"true"`
+		Literal  string `env:"EXPAND_LITERAL" default:"${EXPAND_HOME}"`
+		Fallback string `env:"EXPAND_FALLBACK" default:"${EXPAND_UNSET:-fallback}" expand:"true"`
+	}{}
+
+	if err := LoadStruct(config); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"default referencing env", config.Cache, "/home/user/.cache"},
+		{"value referencing other fields", config.URL, "https://localhost:8080/api"},
+		{"no expand tag", config.Literal, "${EXPAND_HOME}"},
+		{"default value syntax", config.Fallback, "fallback"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, tt.got)
+			}
+		})
+	}
+
+	if config.Port != 8080 {
+		t.Errorf("Expected Port to be 8080, got: %d", config.Port)
+	}
+}
+
+func TestLoadStructWithOptions_Expand(t *testing.T) {
+	_ = os.Setenv("EXPAND_APP_DIR", "/app")
+	defer func() { _ = os.Unsetenv("EXPAND_APP_DIR") }()
+
+	config := &struct {
+		LogDir string `env:"EXPAND_LOG_DIR" default:"$EXPAND_APP_DIR/logs"`
+	}{}
+
+	if err := LoadStructWithOptions(config, LoadOptions{Expand: true}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.LogDir != "/app/logs" {
+		t.Errorf("Expected LogDir to be '/app/logs', got: %q", config.LogDir)
+	}
+}
+
+func TestLoadStruct_ExpandLaterField(t *testing.T) {
+	config := &struct {
+		URL    string `env:"EXPAND_LATER_URL" default:"http://${EXPAND_LATER_HOST}:${EXPAND_LATER_PORT}" expand:"true"`
+		Host   string `env:"EXPAND_LATER_HOST" default:"localhost"`
+		Nested struct {
+			Port string `env:"PORT" default:"${EXPAND_LATER_BASE_PORT}" expand:"true"`
+		} `envPrefix:"EXPAND_LATER_"`
+		BasePort string `env:"EXPAND_LATER_BASE_PORT" default:"8080"`
+	}{}
+
+	if err := LoadStructWithOptions(config, LoadOptions{Lookuper: MapLookuper{}}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.URL != "http://localhost:8080" {
+		t.Errorf("Expected URL to be 'http://localhost:8080', got: %q", config.URL)
+	}
+}
+
+func TestLoadStruct_ExpandCycle(t *testing.T) {
+	config := &struct {
+		A string `env:"EXPAND_CYCLE_A" default:"${EXPAND_CYCLE_B}" expand:"true"`
+		B string `env:"EXPAND_CYCLE_B" default:"${EXPAND_CYCLE_A}" expand:"true"`
+	}{}
+
+	err := LoadStructWithOptions(config, LoadOptions{Lookuper: MapLookuper{}})
+	if !errors.Is(err, ErrParse) {
+		t.Fatalf("Expected ErrParse, got: %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "circular reference to EXPAND_CYCLE_A while expanding field A") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
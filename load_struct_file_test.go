@@ -0,0 +1,104 @@
+package dotenv
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSecretFile(t *testing.T, content string) string {
+	t.Helper()
+
+	location := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(location, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return location
+}
+
+func TestLoadStruct_FileTag(t *testing.T) {
+	_ = os.Setenv("FILE_DB_PASSWORD_FILE", writeSecretFile(t, "s3cret\n"))
+	_ = os.Setenv("FILE_API_KEY", "from_env")
+
+	defer func() {
+		_ = os.Unsetenv("FILE_DB_PASSWORD_FILE")
+		_ = os.Unsetenv("FILE_API_KEY")
+	}()
+
+	config := &struct {
+		Password string `env:"FILE_DB_PASSWORD" file:"true"`
+		APIKey   string `env:"FILE_API_KEY" file:"true"`
+		Token    string `env:"FILE_TOKEN" file:"true" default:"none"`
+	}{}
+
+	if err := LoadStruct(config); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.Password != "s3cret" {
+		t.Errorf("Expected Password to be read from file, got: %q", config.Password)
+	}
+
+	if config.APIKey != "from_env" {
+		t.Errorf("Expected APIKey to be read from env, got: %q", config.APIKey)
+	}
+
+	if config.Token != "none" {
+		t.Errorf("Expected Token to use its default, got: %q", config.Token)
+	}
+}
+
+func TestLoadStructWithOptions_FileSuffix(t *testing.T) {
+	_ = os.Setenv("FILE_PORT_PATH", writeSecretFile(t, "8080\r\n"))
+	defer func() { _ = os.Unsetenv("FILE_PORT_PATH") }()
+
+	config := &struct {
+		Port int `env:"FILE_PORT"`
+	}{}
+
+	if err := LoadStructWithOptions(config, LoadOptions{FileSuffix: "_PATH"}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.Port != 8080 {
+		t.Errorf("Expected Port to be 8080, got: %d", config.Port)
+	}
+}
+
+func TestLoadStruct_FileTagErrors(t *testing.T) {
+	_ = os.Setenv("FILE_BOTH", "value")
+	_ = os.Setenv("FILE_BOTH_FILE", writeSecretFile(t, "value"))
+	_ = os.Setenv("FILE_MISSING_FILE", filepath.Join(t.TempDir(), "missing"))
+
+	defer func() {
+		_ = os.Unsetenv("FILE_BOTH")
+		_ = os.Unsetenv("FILE_BOTH_FILE")
+		_ = os.Unsetenv("FILE_MISSING_FILE")
+	}()
+
+	config := &struct {
+		Both    string `env:"FILE_BOTH" file:"true"`
+		Missing string `env:"FILE_MISSING" file:"true"`
+	}{}
+
+	err := LoadStruct(config)
+
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) || len(loadErr.Errors) != 2 {
+		t.Fatalf("Expected 2 errors, got: %v", err)
+	}
+
+	if loadErr.Errors[0].Error() != "both FILE_BOTH and FILE_BOTH_FILE are set for field Both" {
+		t.Errorf("Unexpected error: %v", loadErr.Errors[0])
+	}
+
+	if !errors.Is(loadErr.Errors[0], ErrConflict) || errors.Is(loadErr.Errors[0], ErrValidation) {
+		t.Errorf("Expected ErrConflict, got kind: %v", loadErr.Errors[0].Kind)
+	}
+
+	if !errors.Is(loadErr.Errors[1], os.ErrNotExist) {
+		t.Errorf("Expected os.ErrNotExist, got: %v", loadErr.Errors[1])
+	}
+}
@@ -62,3 +62,14 @@ func TestLoadStructWithOptions_FormatValidation(t *testing.T) {
 		t.Errorf("Expected email Arnold@example.com, got %s", configB.Email)
 	}
 }
+
+func TestLoadStruct_InvalidTarget(t *testing.T) {
+	var number int
+	var config *struct{}
+
+	for _, data := range []interface{}{nil, struct{}{}, &number, config} {
+		if err := LoadStruct(data); err == nil || err.Error() != "data must be a non-nil pointer to a struct" {
+			t.Errorf("LoadStruct(%T) = %v, want an error", data, err)
+		}
+	}
+}
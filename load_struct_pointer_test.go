@@ -0,0 +1,97 @@
+package dotenv
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLoadStruct_PointerFields(t *testing.T) {
+	_ = os.Setenv("PTR_DEBUG", "false")
+	_ = os.Setenv("PTR_PORT", "8080")
+
+	defer func() {
+		_ = os.Unsetenv("PTR_DEBUG")
+		_ = os.Unsetenv("PTR_PORT")
+	}()
+
+	config := &struct {
+		Debug   *bool    `env:"PTR_DEBUG"`
+		Port    *int     `env:"PTR_PORT"`
+		Name    *string  `env:"PTR_NAME"`
+		Timeout *float64 `env:"PTR_TIMEOUT" default:"1.5"`
+	}{}
+
+	err := LoadStruct(config)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.Debug == nil || *config.Debug != false {
+		t.Errorf("Expected Debug to point to false, got: %v", config.Debug)
+	}
+
+	if config.Port == nil || *config.Port != 8080 {
+		t.Errorf("Expected Port to point to 8080, got: %v", config.Port)
+	}
+
+	if config.Name != nil {
+		t.Errorf("Expected Name to stay nil, got: %q", *config.Name)
+	}
+
+	if config.Timeout == nil || *config.Timeout != 1.5 {
+		t.Errorf("Expected Timeout to point to 1.5, got: %v", config.Timeout)
+	}
+}
+
+func TestLoadStruct_PointerFieldInvalidValue(t *testing.T) {
+	_ = os.Setenv("PTR_INVALID", "not_a_number")
+	defer func() { _ = os.Unsetenv("PTR_INVALID") }()
+
+	config := &struct {
+		Value *int `env:"PTR_INVALID"`
+	}{}
+
+	if err := LoadStruct(config); err == nil {
+		t.Fatal("Expected error for invalid int, got nil")
+	}
+
+	if config.Value != nil {
+		t.Errorf("Expected Value to stay nil on error, got: %d", *config.Value)
+	}
+}
+
+func TestLoadStruct_PointerToStruct(t *testing.T) {
+	_ = os.Setenv("PTR_DB_HOST", "db.local")
+	defer func() { _ = os.Unsetenv("PTR_DB_HOST") }()
+
+	type database struct {
+		Host string `env:"PTR_DB_HOST"`
+		Port int    `env:"PTR_DB_PORT"`
+	}
+
+	type cache struct {
+		URL string `env:"PTR_CACHE_URL"`
+	}
+
+	config := &struct {
+		Database *database
+		Cache    *cache
+	}{}
+
+	err := LoadStruct(config)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.Database == nil {
+		t.Fatal("Expected Database to be allocated")
+	}
+
+	if config.Database.Host != "db.local" {
+		t.Errorf("Expected Database.Host to be 'db.local', got: %s", config.Database.Host)
+	}
+
+	if config.Cache != nil {
+		t.Errorf("Expected Cache to stay nil, got: %+v", config.Cache)
+	}
+}
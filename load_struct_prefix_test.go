@@ -0,0 +1,83 @@
+package dotenv
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLoadStruct_EnvPrefix(t *testing.T) {
+	_ = os.Setenv("PRIMARY_DB_HOST", "primary.local")
+	_ = os.Setenv("REPLICA_DB_HOST", "replica.local")
+	_ = os.Setenv("REPLICA_DB_PORT", "5433")
+
+	defer func() {
+		_ = os.Unsetenv("PRIMARY_DB_HOST")
+		_ = os.Unsetenv("REPLICA_DB_HOST")
+		_ = os.Unsetenv("REPLICA_DB_PORT")
+	}()
+
+	type dbConfig struct {
+		Host string `env:"DB_HOST"`
+		Port int    `env:"DB_PORT" default:"5432"`
+	}
+
+	config := &struct {
+		Primary dbConfig  `envPrefix:"PRIMARY_"`
+		Replica *dbConfig `envPrefix:"REPLICA_"`
+		Backup  *dbConfig `envPrefix:"BACKUP_"`
+	}{}
+
+	err := LoadStruct(config)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.Primary.Host != "primary.local" || config.Primary.Port != 5432 {
+		t.Errorf("Expected Primary to be primary.local:5432, got: %s:%d", config.Primary.Host, config.Primary.Port)
+	}
+
+	if config.Replica == nil {
+		t.Fatal("Expected Replica to be allocated")
+	}
+
+	if config.Replica.Host != "replica.local" || config.Replica.Port != 5433 {
+		t.Errorf("Expected Replica to be replica.local:5433, got: %s:%d", config.Replica.Host, config.Replica.Port)
+	}
+
+	// The default of Port is enough to allocate Backup
+	if config.Backup == nil || config.Backup.Port != 5432 {
+		t.Errorf("Expected Backup to be allocated with default port, got: %+v", config.Backup)
+	}
+}
+
+func TestLoadStructWithOptions_PrefixComposes(t *testing.T) {
+	_ = os.Setenv("APP_CACHE_REDIS_ADDR", "redis:6379")
+	_ = os.Setenv("APP_NAME", "dotenv")
+
+	defer func() {
+		_ = os.Unsetenv("APP_CACHE_REDIS_ADDR")
+		_ = os.Unsetenv("APP_NAME")
+	}()
+
+	config := &struct {
+		Name  string `env:"NAME"`
+		Cache struct {
+			Redis struct {
+				Addr string `env:"ADDR"`
+			} `envPrefix:"REDIS_"`
+		} `envPrefix:"CACHE_"`
+	}{}
+
+	err := LoadStructWithOptions(config, LoadOptions{Prefix: "APP_"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.Name != "dotenv" {
+		t.Errorf("Expected Name to be 'dotenv', got: %s", config.Name)
+	}
+
+	if config.Cache.Redis.Addr != "redis:6379" {
+		t.Errorf("Expected Cache.Redis.Addr to be 'redis:6379', got: %s", config.Cache.Redis.Addr)
+	}
+}
@@ -0,0 +1,195 @@
+package dotenv
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoadStruct_DurationSliceAndTextUnmarshaler(t *testing.T) {
+	_ = os.Setenv("TYPES_TIMEOUT", "1m30s")
+	_ = os.Setenv("TYPES_HOSTS", "a.local, b.local")
+	_ = os.Setenv("TYPES_PORTS", "80;443")
+	_ = os.Setenv("TYPES_IP", "10.0.0.1")
+	_ = os.Setenv("TYPES_SINCE", "2024-01-02T03:04:05Z")
+	_ = os.Setenv("TYPES_EMPTY", "")
+
+	defer func() {
+		for _, key := range []string{"TYPES_TIMEOUT", "TYPES_HOSTS", "TYPES_PORTS", "TYPES_IP", "TYPES_SINCE", "TYPES_EMPTY"} {
+			_ = os.Unsetenv(key)
+		}
+	}()
+
+	config := &struct {
+		Timeout  time.Duration  `env:"TYPES_TIMEOUT"`
+		Interval *time.Duration `env:"TYPES_INTERVAL" default:"5s"`
+		Hosts    []string       `env:"TYPES_HOSTS"`
+		Ports    []int          `env:"TYPES_PORTS" separator:";"`
+		IP       net.IP         `env:"TYPES_IP"`
+		Since    time.Time      `env:"TYPES_SINCE"`
+		Empty    []string       `env:"TYPES_EMPTY"`
+	}{}
+
+	if err := LoadStruct(config); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.Timeout != 90*time.Second {
+		t.Errorf("Expected Timeout to be 1m30s, got: %s", config.Timeout)
+	}
+
+	if config.Interval == nil || *config.Interval != 5*time.Second {
+		t.Errorf("Expected Interval to point to 5s, got: %v", config.Interval)
+	}
+
+	if !reflect.DeepEqual(config.Hosts, []string{"a.local", "b.local"}) {
+		t.Errorf("Expected Hosts to be [a.local b.local], got: %v", config.Hosts)
+	}
+
+	if !reflect.DeepEqual(config.Ports, []int{80, 443}) {
+		t.Errorf("Expected Ports to be [80 443], got: %v", config.Ports)
+	}
+
+	if !config.IP.Equal(net.ParseIP("10.0.0.1")) {
+		t.Errorf("Expected IP to be 10.0.0.1, got: %s", config.IP)
+	}
+
+	if !config.Since.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("Expected Since to be 2024-01-02T03:04:05Z, got: %s", config.Since)
+	}
+
+	if config.Empty == nil || len(config.Empty) != 0 {
+		t.Errorf("Expected Empty to be an empty slice, got: %#v", config.Empty)
+	}
+}
+
+func TestLoadStruct_InvalidDurationAndSlice(t *testing.T) {
+	_ = os.Setenv("TYPES_BAD_TIMEOUT", "90")
+	_ = os.Setenv("TYPES_BAD_PORTS", "80,http")
+
+	defer func() {
+		_ = os.Unsetenv("TYPES_BAD_TIMEOUT")
+		_ = os.Unsetenv("TYPES_BAD_PORTS")
+	}()
+
+	config := &struct {
+		Timeout time.Duration `env:"TYPES_BAD_TIMEOUT"`
+		Ports   []int         `env:"TYPES_BAD_PORTS"`
+	}{}
+
+	err := LoadStruct(config)
+
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) || len(loadErr.Errors) != 2 {
+		t.Fatalf("Expected 2 errors, got: %v", err)
+	}
+
+	if !errors.Is(err, ErrParse) {
+		t.Errorf("Expected ErrParse, got: %v", err)
+	}
+}
+
+func TestLoadStruct_MapAndURL(t *testing.T) {
+	_ = os.Setenv("TYPES_LABELS", "region=eu, tier=gold")
+	_ = os.Setenv("TYPES_LIMITS", "read=10;write=5")
+	_ = os.Setenv("TYPES_URL", "https://example.com/path")
+
+	defer func() {
+		for _, key := range []string{"TYPES_LABELS", "TYPES_LIMITS", "TYPES_URL"} {
+			_ = os.Unsetenv(key)
+		}
+	}()
+
+	config := &struct {
+		Labels   map[string]string `env:"TYPES_LABELS"`
+		Limits   map[string]int    `env:"TYPES_LIMITS" separator:";"`
+		Endpoint *url.URL          `env:"TYPES_URL"`
+		Fallback url.URL           `env:"TYPES_FALLBACK" default:"http://localhost"`
+	}{}
+
+	if err := LoadStruct(config); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !reflect.DeepEqual(config.Labels, map[string]string{"region": "eu", "tier": "gold"}) {
+		t.Errorf("Labels = %v", config.Labels)
+	}
+
+	if !reflect.DeepEqual(config.Limits, map[string]int{"read": 10, "write": 5}) {
+		t.Errorf("Limits = %v", config.Limits)
+	}
+
+	if config.Endpoint == nil || config.Endpoint.String() != "https://example.com/path" {
+		t.Errorf("Endpoint = %v, want https://example.com/path", config.Endpoint)
+	}
+
+	if config.Fallback.Host != "localhost" {
+		t.Errorf("Fallback = %v, want http://localhost", config.Fallback)
+	}
+}
+
+func TestLoadStruct_InvalidMap(t *testing.T) {
+	_ = os.Setenv("TYPES_BAD_LABELS", "region")
+	defer func() { _ = os.Unsetenv("TYPES_BAD_LABELS") }()
+
+	config := &struct {
+		Labels map[string]string `env:"TYPES_BAD_LABELS"`
+	}{}
+
+	err := LoadStruct(config)
+	if !errors.Is(err, ErrParse) {
+		t.Errorf("Expected ErrParse, got: %v", err)
+	}
+}
+
+// typesVersion is a struct read from a single variable through encoding.TextUnmarshaler.
+type typesVersion struct {
+	Major int `env:"TYPES_VERSION_MAJOR"`
+	Minor int `env:"TYPES_VERSION_MINOR"`
+}
+
+func (v *typesVersion) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "%d.%d", &v.Major, &v.Minor)
+	return err
+}
+
+func TestLoadStruct_TextUnmarshalerStructIsNotWalked(t *testing.T) {
+	_ = os.Setenv("TYPES_VERSION", "2.7")
+	_ = os.Setenv("TYPES_VERSION_MAJOR", "9")
+	_ = os.Setenv("TYPES_NESTED_PORT", "8080")
+
+	defer func() {
+		for _, key := range []string{"TYPES_VERSION", "TYPES_VERSION_MAJOR", "TYPES_NESTED_PORT"} {
+			_ = os.Unsetenv(key)
+		}
+	}()
+
+	config := &struct {
+		Version  typesVersion  `env:"TYPES_VERSION"`
+		Previous *typesVersion `env:"TYPES_PREVIOUS_VERSION"`
+		Nested   struct {
+			Port int `env:"PORT"`
+		} `envPrefix:"TYPES_NESTED_"`
+	}{}
+
+	if err := LoadStruct(config); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.Version != (typesVersion{Major: 2, Minor: 7}) {
+		t.Errorf("Expected Version to be read from TYPES_VERSION only, got: %+v", config.Version)
+	}
+
+	if config.Previous != nil {
+		t.Errorf("Expected Previous to stay nil, got: %+v", config.Previous)
+	}
+
+	if config.Nested.Port != 8080 {
+		t.Errorf("Expected a plain nested struct to be walked, got Port: %d", config.Nested.Port)
+	}
+}
@@ -0,0 +1,117 @@
+package dotenv
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+type validatePool struct {
+	MinConns int `env:"MIN_CONNS" default:"1"`
+	MaxConns int `env:"MAX_CONNS" default:"10"`
+}
+
+func (p validatePool) Validate() error {
+	if p.MinConns > p.MaxConns {
+		return fmt.Errorf("MIN_CONNS (%d) must be lower than MAX_CONNS (%d)", p.MinConns, p.MaxConns)
+	}
+	return nil
+}
+
+type validateConfig struct {
+	TLSCert string        `env:"VALIDATE_TLS_CERT"`
+	TLSKey  string        `env:"VALIDATE_TLS_KEY"`
+	Pool    validatePool  `envPrefix:"VALIDATE_"`
+	Replica *validatePool `envPrefix:"VALIDATE_REPLICA_"`
+}
+
+func (c *validateConfig) Validate() error {
+	if (c.TLSCert == "") != (c.TLSKey == "") {
+		return errors.New("VALIDATE_TLS_CERT and VALIDATE_TLS_KEY must be set together")
+	}
+	return nil
+}
+
+func TestLoadStruct_ValidateMethod(t *testing.T) {
+	_ = os.Setenv("VALIDATE_TLS_CERT", "cert.pem")
+	_ = os.Setenv("VALIDATE_MIN_CONNS", "20")
+
+	defer func() {
+		_ = os.Unsetenv("VALIDATE_TLS_CERT")
+		_ = os.Unsetenv("VALIDATE_MIN_CONNS")
+	}()
+
+	config := &validateConfig{}
+
+	err := LoadStruct(config)
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("Expected ErrValidation, got: %v", err)
+	}
+
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) || len(loadErr.Errors) != 2 {
+		t.Fatalf("Expected 2 errors, got: %v", err)
+	}
+
+	// Nested structs are validated before their parent
+	if loadErr.Errors[0].Field != "Pool" {
+		t.Errorf("Expected first error on Pool, got: %q", loadErr.Errors[0].Field)
+	}
+
+	if loadErr.Errors[1].Field != "" {
+		t.Errorf("Expected second error on the root struct, got: %q", loadErr.Errors[1].Field)
+	}
+
+	// Replica is allocated by its defaults, which are valid
+	if config.Replica == nil {
+		t.Error("Expected Replica to be allocated")
+	}
+
+	msg := err.Error()
+	for _, want := range []string{
+		"  - Pool: struct validation failed for field Pool: MIN_CONNS (20) must be lower than MAX_CONNS (10)",
+		"  - struct validation failed: VALIDATE_TLS_CERT and VALIDATE_TLS_KEY must be set together",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected error report to contain %q, got:\n%s", want, msg)
+		}
+	}
+}
+
+func TestLoadStructWithOptions_StructValidators(t *testing.T) {
+	_ = os.Setenv("VALIDATE_TLS_CERT", "cert.pem")
+	_ = os.Setenv("VALIDATE_TLS_KEY", "key.pem")
+
+	defer func() {
+		_ = os.Unsetenv("VALIDATE_TLS_CERT")
+		_ = os.Unsetenv("VALIDATE_TLS_KEY")
+	}()
+
+	called := false
+	opts := LoadOptions{
+		StructValidators: []StructValidator{
+			func(data interface{}) error {
+				called = true
+				config := data.(*validateConfig)
+				if config.TLSCert == config.TLSKey {
+					return errors.New("certificate and key must be different files")
+				}
+				return nil
+			},
+			func(data interface{}) error {
+				return errors.New("always fails")
+			},
+		},
+	}
+
+	err := LoadStructWithOptions(&validateConfig{}, opts)
+	if !called {
+		t.Error("Expected struct validator to be called")
+	}
+
+	if err == nil || err.Error() != "struct validation failed: always fails" {
+		t.Errorf("Expected only the second struct validator to fail, got: %v", err)
+	}
+}
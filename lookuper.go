@@ -0,0 +1,50 @@
+package dotenv
+
+import "os"
+
+// Lookuper retrieves the value of an environment variable,
+// reporting whether it is set as os.LookupEnv does.
+type Lookuper interface {
+	Lookup(key string) (string, bool)
+}
+
+// OSLookuper looks up the environment variables of the process.
+type OSLookuper struct{}
+
+// Lookup implements Lookuper with os.LookupEnv.
+func (OSLookuper) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+// MapLookuper looks up variables in a map, e.g. for tests or values parsed from a file.
+type MapLookuper map[string]string
+
+// Lookup implements Lookuper.
+func (m MapLookuper) Lookup(key string) (string, bool) {
+	value, exists := m[key]
+	return value, exists
+}
+
+// PrefixLookuper prepends Prefix to every key before looking it up in Lookuper.
+type PrefixLookuper struct {
+	Prefix   string
+	Lookuper Lookuper
+}
+
+// Lookup implements Lookuper.
+func (p PrefixLookuper) Lookup(key string) (string, bool) {
+	return p.Lookuper.Lookup(p.Prefix + key)
+}
+
+// MultiLookuper looks up each Lookuper in order and returns the first value found.
+type MultiLookuper []Lookuper
+
+// Lookup implements Lookuper.
+func (m MultiLookuper) Lookup(key string) (string, bool) {
+	for _, lookuper := range m {
+		if value, exists := lookuper.Lookup(key); exists {
+			return value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,108 @@
+package dotenv
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLookupers(t *testing.T) {
+	_ = os.Setenv("LOOKUPER_OS", "from_os")
+	defer func() { _ = os.Unsetenv("LOOKUPER_OS") }()
+
+	defaults := MapLookuper{"HOST": "localhost", "PORT": "8080"}
+	overrides := MapLookuper{"APP_PORT": "9090", "APP_EMPTY": ""}
+
+	lookuper := MultiLookuper{
+		PrefixLookuper{Prefix: "APP_", Lookuper: overrides},
+		defaults,
+		OSLookuper{},
+	}
+
+	tests := []struct {
+		key      string
+		expected string
+		exists   bool
+	}{
+		{"PORT", "9090", true},
+		{"HOST", "localhost", true},
+		{"EMPTY", "", true},
+		{"LOOKUPER_OS", "from_os", true},
+		{"LOOKUPER_MISSING", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			value, exists := lookuper.Lookup(tt.key)
+			if value != tt.expected || exists != tt.exists {
+				t.Errorf("Lookup(%q) = %q, %t, want %q, %t", tt.key, value, exists, tt.expected, tt.exists)
+			}
+		})
+	}
+}
+
+func TestLoadStructWithOptions_Lookuper(t *testing.T) {
+	t.Parallel()
+
+	config := &struct {
+		Host string `env:"HOST" required:"true"`
+		Port int    `env:"PORT"`
+		URL  string `env:"URL" expand:"true" default:"http://${HOST}:${PORT}"`
+	}{}
+
+	opts := LoadOptions{
+		Lookuper: MapLookuper{"HOST": "example.com", "PORT": "8080"},
+	}
+
+	if err := LoadStructWithOptions(config, opts); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.Host != "example.com" || config.Port != 8080 {
+		t.Errorf("Expected example.com:8080, got: %s:%d", config.Host, config.Port)
+	}
+
+	if config.URL != "http://example.com:8080" {
+		t.Errorf("Expected URL to be 'http://example.com:8080', got: %s", config.URL)
+	}
+}
+
+func TestGetterWithLookuper(t *testing.T) {
+	t.Parallel()
+
+	getter := NewGetter(MapLookuper{"NAME": "dotenv", "PORT": "8080", "DEBUG": "true", "RATE": "1.5"})
+
+	if getter.GetString("NAME") != "dotenv" {
+		t.Error("GetString() should return \"dotenv\"")
+	}
+
+	if getter.GetInt("PORT") != 8080 {
+		t.Error("GetInt() should return 8080")
+	}
+
+	if getter.GetBool("DEBUG") != true {
+		t.Error("GetBool() should return true")
+	}
+
+	if getter.GetFloat64OrDefault("RATE", 2) != 1.5 {
+		t.Error("GetFloat64OrDefault() should return 1.5")
+	}
+
+	if getter.GetUintOrDefault("MISSING", 3) != 3 {
+		t.Error("GetUintOrDefault() should return 3")
+	}
+}
+
+func TestRequireFrom(t *testing.T) {
+	t.Parallel()
+
+	lookuper := MapLookuper{"NAME": "dotenv", "EMPTY": ""}
+
+	if err := RequireFrom(lookuper, "NAME"); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	err := RequireFrom(lookuper, "NAME", "EMPTY", "MISSING")
+	if err == nil || err.Error() != "the following environment variables are required: EMPTY, MISSING" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,283 @@
+package dotenv
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MarshalOptions provides configuration options for MarshalWithOptions and MarshalMapWithOptions
+type MarshalOptions struct {
+	// Prefix is prepended to every env key of the struct, before any envPrefix tag
+	Prefix string
+
+	// RedactSecrets masks the values of the fields tagged secret:"true"
+	RedactSecrets bool
+}
+
+// marshalEntry is a variable produced from a struct field.
+type marshalEntry struct {
+	key   string
+	value string
+}
+
+// Marshal returns the .env content of a struct, the reverse of LoadStruct.
+// Fields are written in declaration order, using the env and envPrefix tags.
+// Nil pointers and fields without env tag are skipped.
+//
+// Values are quoted when needed so that Parse reads them back.
+// Parse always substitutes $VAR and ${VAR}, even in quotes, so a value containing one
+// cannot be written and Marshal returns an error. MarshalMap has no such limitation.
+func Marshal(data interface{}) ([]byte, error) {
+	return MarshalWithOptions(data, MarshalOptions{})
+}
+
+// MarshalWithOptions returns the .env content of a struct with additional options
+func MarshalWithOptions(data interface{}, opts MarshalOptions) ([]byte, error) {
+	return marshal(data, opts, true)
+}
+
+// marshal writes the .env content of a struct. When strict is set,
+// values that Parse would not read back are rejected instead of written.
+func marshal(data interface{}, opts MarshalOptions, strict bool) ([]byte, error) {
+	entries, err := marshalEntries(data, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var builder strings.Builder
+	for _, entry := range entries {
+		if strict && isSubstituted(entry.value) {
+			return nil, fmt.Errorf("cannot marshal %s: its value contains a $ substitution that Parse would expand", entry.key)
+		}
+
+		builder.WriteString(entry.key)
+		builder.WriteByte('=')
+		builder.WriteString(quoteValue(entry.value))
+		builder.WriteString(linebreak())
+	}
+
+	return []byte(builder.String()), nil
+}
+
+// MarshalMap returns the variables of a struct, the reverse of LoadStruct.
+// The map can be given to MapLookuper, or to the environment of a child process.
+func MarshalMap(data interface{}) (map[string]string, error) {
+	return MarshalMapWithOptions(data, MarshalOptions{})
+}
+
+// MarshalMapWithOptions returns the variables of a struct with additional options
+func MarshalMapWithOptions(data interface{}, opts MarshalOptions) (map[string]string, error) {
+	entries, err := marshalEntries(data, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		values[entry.key] = entry.value
+	}
+
+	return values, nil
+}
+
+// marshalEntries walks a struct, or a pointer to a struct, and formats its fields.
+func marshalEntries(data interface{}, opts MarshalOptions) ([]marshalEntry, error) {
+	dataValue := reflect.ValueOf(data)
+	if dataValue.Kind() == reflect.Ptr && !dataValue.IsNil() {
+		dataValue = dataValue.Elem()
+	}
+
+	if dataValue.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("data must be a struct or a pointer to a struct")
+	}
+
+	var entries []marshalEntry
+	if err := marshalFields(dataValue, opts.Prefix, opts, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func marshalFields(dataValue reflect.Value, prefix string, opts MarshalOptions, entries *[]marshalEntry) error {
+	dataType := dataValue.Type()
+
+	for i := 0; i < dataType.NumField(); i++ {
+		field := dataType.Field(i)
+		value := dataValue.Field(i)
+
+		// Skip unexported fields
+		if !field.IsExported() {
+			continue
+		}
+
+		if isNestedStruct(field.Type) {
+			if err := marshalFields(value, prefix+field.Tag.Get("envPrefix"), opts, entries); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if value.Kind() == reflect.Ptr && isNestedStruct(field.Type.Elem()) {
+			if value.IsNil() {
+				continue
+			}
+			if err := marshalFields(value.Elem(), prefix+field.Tag.Get("envPrefix"), opts, entries); err != nil {
+				return err
+			}
+			continue
+		}
+
+		envTag := field.Tag.Get("env")
+		if envTag == "" {
+			continue
+		}
+
+		if value.Kind() == reflect.Ptr && value.IsNil() {
+			continue
+		}
+
+		formatted, err := formatValue(value, field)
+		if err != nil {
+			return err
+		}
+
+		if opts.RedactSecrets && field.Tag.Get("secret") == "true" {
+			formatted = Redact(formatted)
+		}
+
+		*entries = append(*entries, marshalEntry{key: prefix + envTag, value: formatted})
+	}
+
+	return nil
+}
+
+// formatValue converts a field value to its environment representation, the reverse of setValue.
+func formatValue(value reflect.Value, field reflect.StructField) (string, error) {
+	if value.Kind() == reflect.Ptr {
+		return formatValue(value.Elem(), field)
+	}
+
+	if marshaler, ok := value.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return "", fmt.Errorf("failed to format %s field %s: %w", value.Type(), field.Name, err)
+		}
+		return string(text), nil
+	}
+
+	if value.Type() == durationType {
+		return time.Duration(value.Int()).String(), nil
+	}
+
+	if value.Type() == urlType {
+		u := value.Interface().(url.URL)
+		return u.String(), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Slice:
+		separator := field.Tag.Get("separator")
+		if separator == "" {
+			separator = defaultSeparator
+		}
+
+		parts := make([]string, value.Len())
+		for i := range parts {
+			part, err := formatValue(value.Index(i), field)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = part
+		}
+		return strings.Join(parts, separator), nil
+	case reflect.Map:
+		return formatMap(value, field)
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), formatBase(field)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), formatBase(field)), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 64), nil
+	default:
+		return "", fmt.Errorf("%w for field %s", ErrUnsupportedType, field.Name)
+	}
+}
+
+// formatBase returns the base of the base tag for writing integers, 10 when it is absent or 0.
+func formatBase(field reflect.StructField) int {
+	base, err := numberBase(field)
+	if err != nil || base == 0 {
+		return 10
+	}
+	return base
+}
+
+// formatMap writes the entries of a map as key=value pairs, sorted by key for a stable output.
+func formatMap(value reflect.Value, field reflect.StructField) (string, error) {
+	separator := field.Tag.Get("separator")
+	if separator == "" {
+		separator = defaultSeparator
+	}
+
+	parts := make([]string, 0, value.Len())
+	iter := value.MapRange()
+	for iter.Next() {
+		key, err := formatValue(iter.Key(), field)
+		if err != nil {
+			return "", err
+		}
+		elem, err := formatValue(iter.Value(), field)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, key+defaultKeyValueSeparator+elem)
+	}
+	sort.Strings(parts)
+
+	return strings.Join(parts, separator), nil
+}
+
+// isSubstituted reports whether Parse would substitute a part of value, as in "pa$word".
+func isSubstituted(value string) bool {
+	referenced := false
+	expanded := expand(value, func(string) (string, bool) {
+		referenced = true
+		return "", false
+	})
+	return referenced || expanded != value
+}
+
+// quoteValue wraps a value in double quotes, escaping it, when Parse would not read it back as is.
+func quoteValue(value string) string {
+	if value == "" {
+		return value
+	}
+
+	needsQuotes := strings.TrimSpace(value) != value || strings.ContainsAny(value, " #\"'\\\n\r\t")
+	if !needsQuotes {
+		return value
+	}
+
+	replacer := strings.NewReplacer(
+		"\\", "\\\\",
+		"\"", "\\\"",
+		"\n", "\\n",
+		"\r", "\\r",
+		"\t", "\\t",
+	)
+
+	return "\"" + replacer.Replace(value) + "\""
+}
@@ -0,0 +1,196 @@
+package dotenv
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type marshalDatabase struct {
+	Host string `env:"DB_HOST"`
+	Port int    `env:"DB_PORT"`
+}
+
+type marshalConfig struct {
+	Name     string           `env:"MARSHAL_NAME"`
+	Message  string           `env:"MARSHAL_MESSAGE"`
+	Debug    bool             `env:"MARSHAL_DEBUG"`
+	Ratio    float64          `env:"MARSHAL_RATIO"`
+	Timeout  time.Duration    `env:"MARSHAL_TIMEOUT"`
+	Hosts    []string         `env:"MARSHAL_HOSTS"`
+	Ports    []uint16         `env:"MARSHAL_PORTS" separator:";"`
+	IP       net.IP           `env:"MARSHAL_IP"`
+	APIKey   string           `env:"MARSHAL_API_KEY" secret:"true"`
+	Limit    *int             `env:"MARSHAL_LIMIT"`
+	Labels   map[string]int   `env:"MARSHAL_LABELS"`
+	Endpoint *url.URL         `env:"MARSHAL_ENDPOINT"`
+	Primary  marshalDatabase  `envPrefix:"PRIMARY_"`
+	Replica  *marshalDatabase `envPrefix:"REPLICA_"`
+	Internal string
+}
+
+func newMarshalConfig() marshalConfig {
+	return marshalConfig{
+		Name:    "dotenv",
+		Message: "hello \"world\"\nbye # not a comment",
+		Debug:   true,
+		Ratio:   0.75,
+		Timeout: 90 * time.Second,
+		Hosts:   []string{"a.local", "b.local"},
+		Ports:   []uint16{80, 443},
+		IP:      net.ParseIP("10.0.0.1"),
+		APIKey:  "sk_live_1234567890ab12",
+		Labels:  map[string]int{"b": 2, "a": 1},
+		Primary: marshalDatabase{Host: "db.local", Port: 5432},
+	}
+}
+
+func TestMarshalMap(t *testing.T) {
+	config := newMarshalConfig()
+
+	values, err := MarshalMap(&config)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := map[string]string{
+		"MARSHAL_NAME":    "dotenv",
+		"MARSHAL_MESSAGE": "hello \"world\"\nbye # not a comment",
+		"MARSHAL_DEBUG":   "true",
+		"MARSHAL_RATIO":   "0.75",
+		"MARSHAL_TIMEOUT": "1m30s",
+		"MARSHAL_HOSTS":   "a.local,b.local",
+		"MARSHAL_PORTS":   "80;443",
+		"MARSHAL_IP":      "10.0.0.1",
+		"MARSHAL_API_KEY": "sk_live_1234567890ab12",
+		"MARSHAL_LABELS":  "a=1,b=2",
+		"PRIMARY_DB_HOST": "db.local",
+		"PRIMARY_DB_PORT": "5432",
+	}
+
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("MarshalMap() = %v, want %v", values, expected)
+	}
+}
+
+func TestMarshalMapWithOptions_RedactSecrets(t *testing.T) {
+	values, err := MarshalMapWithOptions(newMarshalConfig(), MarshalOptions{Prefix: "APP_", RedactSecrets: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if values["APP_MARSHAL_API_KEY"] != "****ab12" {
+		t.Errorf("Expected API key to be redacted, got: %s", values["APP_MARSHAL_API_KEY"])
+	}
+
+	if values["APP_MARSHAL_NAME"] != "dotenv" {
+		t.Errorf("Expected name not to be redacted, got: %s", values["APP_MARSHAL_NAME"])
+	}
+}
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	limit := 10
+	config := newMarshalConfig()
+	config.Limit = &limit
+	config.Endpoint = &url.URL{Scheme: "https", Host: "example.com", Path: "/v1"}
+	config.Replica = &marshalDatabase{Host: "replica.local", Port: 5433}
+
+	content, err := Marshal(config)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	location := filepath.Join(t.TempDir(), ".env")
+	if err = os.WriteFile(location, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var loaded marshalConfig
+	if err = UnmarshalFile(location, &loaded); err != nil {
+		t.Fatalf("Expected no error, got: %v\n%s", err, content)
+	}
+
+	config.Internal = ""
+	if !reflect.DeepEqual(loaded, config) {
+		t.Errorf("Round trip mismatch:\n got: %+v\nwant: %+v\n%s", loaded, config, content)
+	}
+}
+
+func TestMarshal_Errors(t *testing.T) {
+	if _, err := Marshal("not a struct"); err == nil {
+		t.Error("Expected error for non struct data, got nil")
+	}
+
+	config := struct {
+		Values chan int `env:"MARSHAL_CHAN"`
+	}{Values: make(chan int)}
+
+	if _, err := Marshal(config); err == nil {
+		t.Error("Expected error for unsupported type, got nil")
+	}
+}
+
+func TestMarshal_Substitution(t *testing.T) {
+	config := struct {
+		Password string `env:"PROBE_PW" secret:"true"`
+	}{Password: "pa$word"}
+
+	_, err := Marshal(config)
+	if err == nil || !strings.Contains(err.Error(), "PROBE_PW") || strings.Contains(err.Error(), "pa$word") {
+		t.Errorf("Expected a substitution error naming the key without the value, got: %v", err)
+	}
+
+	for _, value := range []string{"${HOME}", "${UNSET:-x}", "$USER"} {
+		config.Password = value
+		if _, err = Marshal(config); err == nil {
+			t.Errorf("Expected error for %q, got nil", value)
+		}
+	}
+
+	for _, value := range []string{"price: 5$", "a $ b", "cost $5"} {
+		config.Password = value
+		if _, err = Marshal(config); err != nil {
+			t.Errorf("Expected no error for %q, got: %v", value, err)
+		}
+	}
+
+	config.Password = "pa$word"
+	if values, err := MarshalMap(config); err != nil || values["PROBE_PW"] != "pa$word" {
+		t.Errorf("MarshalMap() = %v, %v", values, err)
+	}
+
+	if dump := Dump(struct {
+		Path string `env:"PROBE_PATH"`
+	}{Path: "$HOME/bin"}); dump != "PROBE_PATH=$HOME/bin"+linebreak() {
+		t.Errorf("Dump() = %q", dump)
+	}
+}
+
+func TestQuoteValue(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"", ""},
+		{"simple", "simple"},
+		{"https://example.com/path?q=1", "https://example.com/path?q=1"},
+		{"with spaces", `"with spaces"`},
+		{" leading", `" leading"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{"line1\nline2", `"line1\nline2"`},
+		{`C:\path`, `"C:\\path"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := quoteValue(tt.value); got != tt.expected {
+				t.Errorf("quoteValue(%q) = %s, want %s", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package dotenv
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Values of the format tag.
+const (
+	formatBytes   = "bytes"
+	formatPercent = "percent"
+)
+
+// byteUnits maps the byte size suffixes, compared case-insensitively, to their multiplier.
+var byteUnits = map[string]uint64{
+	"":    1,
+	"b":   1,
+	"k":   1e3,
+	"kb":  1e3,
+	"m":   1e6,
+	"mb":  1e6,
+	"g":   1e9,
+	"gb":  1e9,
+	"t":   1e12,
+	"tb":  1e12,
+	"p":   1e15,
+	"pb":  1e15,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"pib": 1 << 50,
+}
+
+// parseBytes parses a decimal byte size such as "512", "10MB", "1.5 GiB" or "1_000_000".
+// KB, MB... are powers of 1000, KiB, MiB... powers of 1024.
+func parseBytes(value string) (uint64, error) {
+	trimmed := strings.TrimSpace(value)
+	end := strings.IndexFunc(trimmed, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '_'
+	})
+	if end < 0 {
+		end = len(trimmed)
+	}
+
+	number, unit := trimmed[:end], strings.ToLower(strings.TrimSpace(trimmed[end:]))
+	multiplier, ok := byteUnits[unit]
+	if number == "" || !ok || !validUnderscores(number) {
+		return 0, fmt.Errorf("invalid byte size %q", value)
+	}
+
+	// Sizes are always decimal, a leading zero does not mean octal
+	number = strings.ReplaceAll(number, "_", "")
+
+	if !strings.Contains(number, ".") {
+		size, err := strconv.ParseUint(number, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid byte size %q", value)
+		}
+		hi, result := bits.Mul64(size, multiplier)
+		if hi != 0 {
+			return 0, fmt.Errorf("byte size %q is too large: %w", value, strconv.ErrRange)
+		}
+		return result, nil
+	}
+
+	size, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q", value)
+	}
+	result := size * float64(multiplier)
+	if result >= math.MaxUint64 {
+		return 0, fmt.Errorf("byte size %q is too large: %w", value, strconv.ErrRange)
+	}
+	return uint64(result), nil
+}
+
+// validUnderscores reports whether the underscores of number only separate digits, as in 1_000_000.
+func validUnderscores(number string) bool {
+	for i := 0; i < len(number); i++ {
+		if number[i] != '_' {
+			continue
+		}
+		if i == 0 || i == len(number)-1 || !isDigit(number[i-1]) || !isDigit(number[i+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// parseBytesInt64 is parseBytes for signed sizes.
+func parseBytesInt64(value string) (int64, error) {
+	size, err := parseBytes(value)
+	if err != nil {
+		return 0, err
+	}
+	if size > math.MaxInt64 {
+		return 0, fmt.Errorf("byte size %q is too large: %w", value, strconv.ErrRange)
+	}
+	return int64(size), nil
+}
+
+// parsePercent parses a percentage such as "75%" to 0.75. A value without "%" is a ratio, e.g. "0.75".
+func parsePercent(value string) (float64, error) {
+	trimmed := strings.TrimSpace(value)
+	number, isPercent := strings.CutSuffix(trimmed, "%")
+
+	result, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid percentage %q", value)
+	}
+	if isPercent {
+		result /= 100
+	}
+	return result, nil
+}
+
+// parseIntField parses the value of an int field according to its format and base tags.
+func parseIntField(field reflect.StructField, value string) (int64, error) {
+	switch format := field.Tag.Get("format"); format {
+	case "":
+		base, err := numberBase(field)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.ParseInt(value, base, 64)
+	case formatBytes:
+		return parseBytesInt64(value)
+	default:
+		return 0, fmt.Errorf("format %q is not supported for int fields", format)
+	}
+}
+
+// parseUintField parses the value of a uint field according to its format and base tags.
+func parseUintField(field reflect.StructField, value string) (uint64, error) {
+	switch format := field.Tag.Get("format"); format {
+	case "":
+		base, err := numberBase(field)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.ParseUint(value, base, 64)
+	case formatBytes:
+		return parseBytes(value)
+	default:
+		return 0, fmt.Errorf("format %q is not supported for uint fields", format)
+	}
+}
+
+// parseFloatField parses the value of a float field according to its format tag.
+func parseFloatField(field reflect.StructField, value string) (float64, error) {
+	switch format := field.Tag.Get("format"); format {
+	case "":
+		return strconv.ParseFloat(value, 64)
+	case formatBytes:
+		size, err := parseBytes(value)
+		return float64(size), err
+	case formatPercent:
+		return parsePercent(value)
+	default:
+		return 0, fmt.Errorf("format %q is not supported for float fields", format)
+	}
+}
+
+// numberBase returns the integer base of the base tag, 10 when it is absent.
+// Base 0 reads the 0x, 0o and 0b prefixes and underscores.
+func numberBase(field reflect.StructField) (int, error) {
+	tag := field.Tag.Get("base")
+	if tag == "" {
+		return 10, nil
+	}
+
+	base, err := strconv.Atoi(tag)
+	if err != nil || base == 1 || base < 0 || base > 36 {
+		return 0, fmt.Errorf("invalid base %q for field %s", tag, field.Name)
+	}
+	return base, nil
+}
+
+// rangeError reports a number that does not fit in the type of its field.
+type rangeError struct {
+	value string
+	typ   reflect.Type
+}
+
+func newRangeError(typ reflect.Type, value string) *rangeError {
+	return &rangeError{value: value, typ: typ}
+}
+
+func (e *rangeError) Error() string {
+	var min, max string
+
+	switch e.typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bitSize := e.typ.Bits()
+		min = strconv.FormatInt(-1<<(bitSize-1), 10)
+		max = strconv.FormatInt(1<<(bitSize-1)-1, 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		min = "0"
+		max = strconv.FormatUint(math.MaxUint64>>(64-e.typ.Bits()), 10)
+	default:
+		limit := math.MaxFloat64
+		if e.typ.Kind() == reflect.Float32 {
+			limit = math.MaxFloat32
+		}
+		min = strconv.FormatFloat(-limit, 'g', -1, e.typ.Bits())
+		max = strconv.FormatFloat(limit, 'g', -1, e.typ.Bits())
+	}
+
+	return fmt.Sprintf("value %s is out of range [%s, %s] for %s", e.value, min, max, e.typ)
+}
+
+// Unwrap returns strconv.ErrRange, so that errors.Is(err, strconv.ErrRange) reports range errors.
+func (e *rangeError) Unwrap() error {
+	return strconv.ErrRange
+}
@@ -0,0 +1,229 @@
+package dotenv
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"testing"
+)
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected uint64
+		wantErr  bool
+	}{
+		{"512", 512, false},
+		{"512B", 512, false},
+		{"10KB", 10_000, false},
+		{"10kb", 10_000, false},
+		{"10MiB", 10 << 20, false},
+		{"10 MiB", 10 << 20, false},
+		{"1.5GiB", 3 << 29, false},
+		{"2G", 2_000_000_000, false},
+		{"1_000_000", 1_000_000, false},
+		{"0100MB", 100_000_000, false},
+		{"08MB", 8_000_000, false},
+		{"010", 10, false},
+		{"1_5.5KB", 15_500, false},
+		{"_100", 0, true},
+		{"100_", 0, true},
+		{"1__000", 0, true},
+		{"0x10", 0, true},
+		{"16384PiB", 0, true},
+		{"MiB", 0, true},
+		{"10XB", 0, true},
+		{"-1MB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseBytes(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBytes(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("parseBytes(%q) = %d, want %d", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParsePercent(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected float64
+		wantErr  bool
+	}{
+		{"75%", 0.75, false},
+		{"12.5 %", 0.125, false},
+		{"0.75", 0.75, false},
+		{"150%", 1.5, false},
+		{"%", 0, true},
+		{"half", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parsePercent(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePercent(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("parsePercent(%q) = %v, want %v", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+type numberConfig struct {
+	MaxUpload int64   `env:"NUMBER_MAX_UPLOAD" format:"bytes"`
+	Buffer    uint32  `env:"NUMBER_BUFFER" format:"bytes" default:"64KiB"`
+	Ratio     float64 `env:"NUMBER_RATIO" format:"percent"`
+	Mask      uint8   `env:"NUMBER_MASK" base:"0"`
+	Limit     int     `env:"NUMBER_LIMIT" base:"0"`
+	Color     int     `env:"NUMBER_COLOR" base:"16"`
+}
+
+func TestLoadStruct_NumberFormats(t *testing.T) {
+	_ = os.Setenv("NUMBER_MAX_UPLOAD", "10MiB")
+	_ = os.Setenv("NUMBER_RATIO", "75%")
+	_ = os.Setenv("NUMBER_MASK", "0xff")
+	_ = os.Setenv("NUMBER_LIMIT", "1_000_000")
+	_ = os.Setenv("NUMBER_COLOR", "ff8800")
+
+	defer func() {
+		for _, key := range []string{"NUMBER_MAX_UPLOAD", "NUMBER_RATIO", "NUMBER_MASK", "NUMBER_LIMIT", "NUMBER_COLOR"} {
+			_ = os.Unsetenv(key)
+		}
+	}()
+
+	config := &numberConfig{}
+	if err := LoadStruct(config); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := numberConfig{MaxUpload: 10 << 20, Buffer: 64 << 10, Ratio: 0.75, Mask: 0xff, Limit: 1_000_000, Color: 0xff8800}
+	if *config != expected {
+		t.Errorf("LoadStruct() = %+v, want %+v", *config, expected)
+	}
+
+	values, err := MarshalMap(config)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if values["NUMBER_COLOR"] != "ff8800" || values["NUMBER_MAX_UPLOAD"] != "10485760" {
+		t.Errorf("MarshalMap() = %v", values)
+	}
+}
+
+func TestLoadStruct_InvalidNumberFormats(t *testing.T) {
+	_ = os.Setenv("NUMBER_BAD", "10MiB")
+	defer func() { _ = os.Unsetenv("NUMBER_BAD") }()
+
+	tests := []struct {
+		name string
+		data interface{}
+	}{
+		{"decimal", &struct {
+			Value int `env:"NUMBER_BAD"`
+		}{}},
+		{"percent on int", &struct {
+			Value int `env:"NUMBER_BAD" format:"percent"`
+		}{}},
+		{"unknown format", &struct {
+			Value float64 `env:"NUMBER_BAD" format:"duration"`
+		}{}},
+		{"invalid base", &struct {
+			Value int `env:"NUMBER_BAD" base:"hex"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := LoadStruct(tt.data); !errors.Is(err, ErrParse) {
+				t.Errorf("Expected ErrParse, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetterNumberFormats(t *testing.T) {
+	lookuper := MapLookuper{"MAX_UPLOAD": "10MiB", "RATIO": "75%", "MASK": "0xff", "LIMIT": "1_000_000"}
+	getter := NewGetter(lookuper)
+
+	if getter.GetBytes("MAX_UPLOAD") != 10<<20 || getter.GetPercent("RATIO") != 0.75 {
+		t.Error("GetBytes() and GetPercent() returned unexpected values")
+	}
+
+	if getter.GetBytesOrDefault("RATIO", 1) != 1 || getter.GetPercentOrDefault("MASK", 1) != 1 {
+		t.Error("GetBytesOrDefault() and GetPercentOrDefault() should fall back on invalid values")
+	}
+
+	if getter.GetIntOrDefault("MASK", 1) != 1 {
+		t.Error("GetIntOrDefault() should reject hex values without AutoBase")
+	}
+
+	auto := NewGetterWithOptions(lookuper, GetterOptions{AutoBase: true})
+	if auto.GetUint8("MASK") != 0xff || auto.GetInt("LIMIT") != 1_000_000 {
+		t.Errorf("AutoBase getters = %d, %d", auto.GetUint8("MASK"), auto.GetInt("LIMIT"))
+	}
+}
+
+func TestLoadStruct_Overflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		data     interface{}
+		expected string
+	}{
+		{"int8", "300", &struct {
+			Value int8 `env:"NUMBER_OVERFLOW"`
+		}{}, "failed to parse int field Value: value 300 is out of range [-128, 127] for int8"},
+		{"int8 negative", "-129", &struct {
+			Value int8 `env:"NUMBER_OVERFLOW"`
+		}{}, "failed to parse int field Value: value -129 is out of range [-128, 127] for int8"},
+		{"uint16", "70000", &struct {
+			Value uint16 `env:"NUMBER_OVERFLOW"`
+		}{}, "failed to parse uint field Value: value 70000 is out of range [0, 65535] for uint16"},
+		{"int64", "9223372036854775808", &struct {
+			Value int64 `env:"NUMBER_OVERFLOW"`
+		}{}, "failed to parse int field Value: value 9223372036854775808 is out of range [-9223372036854775808, 9223372036854775807] for int64"},
+		{"uint32 bytes", "8GiB", &struct {
+			Value uint32 `env:"NUMBER_OVERFLOW" format:"bytes"`
+		}{}, "failed to parse uint field Value: value 8GiB is out of range [0, 4294967295] for uint32"},
+		{"float32", "1e40", &struct {
+			Value float32 `env:"NUMBER_OVERFLOW"`
+		}{}, "failed to parse float field Value: value 1e40 is out of range [-3.4028235e+38, 3.4028235e+38] for float32"},
+		{"int8 slice", "1,200", &struct {
+			Value []int8 `env:"NUMBER_OVERFLOW"`
+		}{}, "failed to parse int field Value: value 200 is out of range [-128, 127] for int8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = os.Setenv("NUMBER_OVERFLOW", tt.value)
+			defer func() { _ = os.Unsetenv("NUMBER_OVERFLOW") }()
+
+			err := LoadStruct(tt.data)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+
+			if err.Error() != tt.expected {
+				t.Errorf("Expected error %q, got %q", tt.expected, err.Error())
+			}
+
+			if !errors.Is(err, ErrParse) || !errors.Is(err, strconv.ErrRange) {
+				t.Errorf("Expected ErrParse and strconv.ErrRange, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestLookupFrom_Overflow(t *testing.T) {
+	_, _, err := LookupFrom[uint8](MapLookuper{"PORT": "300"}, "PORT")
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("Expected strconv.ErrRange, got: %v", err)
+	}
+}
@@ -18,6 +18,53 @@ import (
 // - Escape sequences in quoted strings
 // - Leading/trailing whitespace trimming
 func Parse(location string) error {
+	err := parseFile(location, os.LookupEnv, func(key, value string, origin fileOrigin) error {
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+		recordOrigin(key, origin)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	recordFile(location)
+	return nil
+}
+
+// Read parses the .env file located at the given location and returns its variables,
+// without setting the environment variables.
+// Substitutions refer to the variables defined earlier in the file, then to the environment variables.
+func Read(location string) (map[string]string, error) {
+	file, err := readFile(location)
+	if err != nil {
+		return nil, err
+	}
+	return file.values, nil
+}
+
+// readFile is Read, also keeping where every variable is defined.
+func readFile(location string) (fileLookuper, error) {
+	file := fileLookuper{values: map[string]string{}, origins: map[string]fileOrigin{}}
+	lookuper := MultiLookuper{MapLookuper(file.values), OSLookuper{}}
+
+	err := parseFile(location, lookuper.Lookup, func(key, value string, origin fileOrigin) error {
+		file.values[key] = value
+		file.origins[key] = origin
+		return nil
+	})
+	if err != nil {
+		return fileLookuper{}, err
+	}
+
+	return file, nil
+}
+
+// parseFile parses the .env file located at the given location.
+// Substitutions are resolved with lookup, and every variable is passed to set
+// with the location of its definition.
+func parseFile(location string, lookup func(string) (string, bool), set func(key, value string, origin fileOrigin) error) error {
 	file, err := os.Open(location)
 	if err != nil {
 		return err
@@ -54,6 +101,7 @@ func Parse(location string) error {
 
 		// Get raw value (everything after =)
 		rawValue := line[equalIndex+1:]
+		startLine := lineNum
 
 		// Handle multiline values and backslash continuation
 		rawValue, lineNum, err = readFullValue(rawValue, scanner, &lineNum)
@@ -65,9 +113,14 @@ func Parse(location string) error {
 		value := processEnvValue(rawValue)
 
 		// Variable substitution
-		value = processSubstitution(value)
-
-		if err = os.Setenv(key, value); err != nil {
+		var references []string
+		value = expand(value, func(name string) (string, bool) {
+			references = append(references, name)
+			return lookup(name)
+		})
+
+		origin := fileOrigin{file: location, line: startLine, value: value, references: references}
+		if err = set(key, value, origin); err != nil {
 			return err
 		}
 	}
@@ -258,6 +311,11 @@ func stripExportPrefix(line string) string {
 //   - ${VAR:-default} : use default if VAR is unset or empty
 //   - ${VAR-default}  : use default only if VAR is unset
 func processSubstitution(value string) string {
+	return expand(value, os.LookupEnv)
+}
+
+// expand is processSubstitution with variables resolved by lookup.
+func expand(value string, lookup func(string) (string, bool)) string {
 	var result strings.Builder
 	i := 0
 
@@ -267,7 +325,7 @@ func processSubstitution(value string) string {
 				// ${VAR} syntax - find matching closing brace
 				content, end := extractBracedContent(value, i+2)
 				if end > i {
-					result.WriteString(resolveWithDefault(content))
+					result.WriteString(resolveWithDefault(content, lookup))
 					i = end
 					continue
 				}
@@ -278,7 +336,8 @@ func processSubstitution(value string) string {
 					end++
 				}
 				name := value[i+1 : end]
-				result.WriteString(os.Getenv(name))
+				val, _ := lookup(name)
+				result.WriteString(val)
 				i = end
 				continue
 			}
@@ -324,14 +383,14 @@ func isIdentifierChar(b byte) bool {
 }
 
 // resolveWithDefault handles ${VAR}, ${VAR:-default}, and ${VAR-default} syntax.
-func resolveWithDefault(content string) string {
+func resolveWithDefault(content string, lookup func(string) (string, bool)) string {
 	// Check for :- (use default if unset OR empty)
 	if idx := strings.Index(content, ":-"); idx != -1 {
 		name := content[:idx]
 		defaultVal := content[idx+2:]
-		val, exists := os.LookupEnv(name)
+		val, exists := lookup(name)
 		if !exists || val == "" {
-			return processSubstitution(defaultVal) // Allow nested substitution
+			return expand(defaultVal, lookup) // Allow nested substitution
 		}
 		return val
 	}
@@ -340,13 +399,14 @@ func resolveWithDefault(content string) string {
 	if idx := strings.Index(content, "-"); idx != -1 {
 		name := content[:idx]
 		defaultVal := content[idx+1:]
-		val, exists := os.LookupEnv(name)
+		val, exists := lookup(name)
 		if !exists {
-			return processSubstitution(defaultVal) // Allow nested substitution
+			return expand(defaultVal, lookup) // Allow nested substitution
 		}
 		return val
 	}
 
 	// No default syntax, just get the variable
-	return os.Getenv(content)
+	val, _ := lookup(content)
+	return val
 }
@@ -39,3 +39,20 @@ func TestParseNoKey(t *testing.T) {
 		t.Errorf(".env line 2 doesnt contains key but OK ?")
 	}
 }
+
+func TestRead(t *testing.T) {
+	_ = os.Unsetenv("UNMARSHAL_HOST")
+
+	values, err := Read("test/test_unmarshal.env")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if values["UNMARSHAL_URL"] != "postgres://db.local:5433/app" {
+		t.Errorf("UNMARSHAL_URL should be substituted from the file, got %s", values["UNMARSHAL_URL"])
+	}
+
+	if _, exists := os.LookupEnv("UNMARSHAL_HOST"); exists {
+		t.Errorf("Read should not set environment variables")
+	}
+}
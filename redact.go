@@ -0,0 +1,26 @@
+package dotenv
+
+// redactVisible is the number of trailing characters kept by Redact.
+const redactVisible = 4
+
+// Redact masks a secret value, only keeping its last 4 characters when it is long enough
+// for them not to give it away, e.g. "****ab12".
+func Redact(value string) string {
+	// Characters are counted as runes, so that a multi-byte character is never cut
+	runes := []rune(value)
+	if len(runes) <= 2*redactVisible {
+		return "****"
+	}
+	return "****" + string(runes[len(runes)-redactVisible:])
+}
+
+// Dump returns the .env content of a struct with the values of the fields tagged secret:"true" redacted.
+// It is meant for debug output and String methods, so an error is written in the result instead of returned,
+// and values containing a $ substitution are written as is.
+func Dump(data interface{}) string {
+	content, err := marshal(data, MarshalOptions{RedactSecrets: true}, false)
+	if err != nil {
+		return "dotenv: " + err.Error()
+	}
+	return string(content)
+}
@@ -0,0 +1,190 @@
+package dotenv
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"", "****"},
+		{"short", "****"},
+		{"12345678", "****"},
+		{"sk_live_abcdef12", "****ef12"},
+		{"pässwörd", "****"},
+		{"motdepasse-été", "****-été"},
+		{"clé-secrète-🔑🔑", "****e-🔑🔑"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := Redact(tt.value); got != tt.expected {
+				t.Errorf("Redact(%q) = %q, want %q", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadStruct_SecretFieldErrorsAreRedacted(t *testing.T) {
+	_ = os.Setenv("SECRET_PIN", "98765x432100")
+	_ = os.Setenv("SECRET_TOKEN", "tok_abcdefgh1234")
+	_ = os.Setenv("SECRET_PORT", "80x")
+
+	defer func() {
+		_ = os.Unsetenv("SECRET_PIN")
+		_ = os.Unsetenv("SECRET_TOKEN")
+		_ = os.Unsetenv("SECRET_PORT")
+	}()
+
+	config := &struct {
+		PIN   int    `env:"SECRET_PIN" secret:"true"`
+		Token string `env:"SECRET_TOKEN" secret:"true" validator:"min=32"`
+		Port  int    `env:"SECRET_PORT"`
+	}{}
+
+	err := LoadStruct(config)
+
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) || len(loadErr.Errors) != 3 {
+		t.Fatalf("Expected 3 errors, got: %v", err)
+	}
+
+	msg := err.Error()
+	for _, secret := range []string{"98765x432100", "tok_abcdefgh1234"} {
+		if strings.Contains(msg, secret) {
+			t.Errorf("Expected secret %q to be redacted, got:\n%s", secret, msg)
+		}
+	}
+
+	if !strings.Contains(msg, `PIN (SECRET_PIN="****2100")`) {
+		t.Errorf("Expected redacted value in report, got:\n%s", msg)
+	}
+
+	if !strings.Contains(msg, `Port (SECRET_PORT="80x")`) {
+		t.Errorf("Expected non secret value in report, got:\n%s", msg)
+	}
+
+	pinErr := loadErr.Errors[0]
+	if !pinErr.Secret || pinErr.Value != "****2100" {
+		t.Errorf("Expected redacted secret field error, got: %+v", pinErr)
+	}
+
+	if !errors.Is(pinErr, ErrParse) {
+		t.Errorf("Expected ErrParse to be preserved, got: %v", pinErr)
+	}
+}
+
+func TestDump(t *testing.T) {
+	config := struct {
+		Host   string `env:"DUMP_HOST"`
+		APIKey string `env:"DUMP_API_KEY" secret:"true"`
+	}{Host: "localhost", APIKey: "sk_live_abcdef12"}
+
+	expected := "DUMP_HOST=localhost" + linebreak() + "DUMP_API_KEY=****ef12" + linebreak()
+	if got := Dump(config); got != expected {
+		t.Errorf("Dump() = %q, want %q", got, expected)
+	}
+
+	if got := Dump(42); !strings.HasPrefix(got, "dotenv: ") {
+		t.Errorf("Dump() should describe the error, got %q", got)
+	}
+}
+
+func TestLoadStruct_ShortSecretErrorsAreRedacted(t *testing.T) {
+	_ = os.Setenv("SECRET_SHORT_PIN", "t")
+	_ = os.Setenv("SECRET_SHORT_CODE", "a9")
+	_ = os.Setenv("SECRET_SHORT_LEVEL", "300")
+
+	defer func() {
+		_ = os.Unsetenv("SECRET_SHORT_PIN")
+		_ = os.Unsetenv("SECRET_SHORT_CODE")
+		_ = os.Unsetenv("SECRET_SHORT_LEVEL")
+	}()
+
+	config := &struct {
+		Pin   int   `env:"SECRET_SHORT_PIN" secret:"true"`
+		Code  []int `env:"SECRET_SHORT_CODE" secret:"true"`
+		Level int8  `env:"SECRET_SHORT_LEVEL" secret:"true"`
+	}{}
+
+	err := LoadStruct(config)
+
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) || len(loadErr.Errors) != 3 {
+		t.Fatalf("Expected 3 errors, got: %v", err)
+	}
+
+	expected := []string{
+		`failed to parse int field Pin: strconv.ParseInt: parsing "****": invalid syntax`,
+		`failed to parse int field Code: strconv.ParseInt: parsing "****": invalid syntax`,
+		`failed to parse int field Level: value **** is out of range [-128, 127] for int8`,
+	}
+	for i, fieldErr := range loadErr.Errors {
+		if fieldErr.Error() != expected[i] {
+			t.Errorf("Expected %q, got %q", expected[i], fieldErr.Error())
+		}
+	}
+
+	if !errors.Is(loadErr.Errors[0], strconv.ErrSyntax) || !errors.Is(loadErr.Errors[2], strconv.ErrRange) {
+		t.Errorf("Expected the strconv sentinel errors to be preserved, got: %v", err)
+	}
+}
+
+func TestLoadStruct_SecretCausesAreRedacted(t *testing.T) {
+	_ = os.Setenv("SECRET_CAUSE_PIN", "98765x432100")
+	defer func() {
+		_ = os.Unsetenv("SECRET_CAUSE_PIN")
+	}()
+
+	config := &struct {
+		PIN int `env:"SECRET_CAUSE_PIN" secret:"true"`
+	}{}
+
+	err := LoadStruct(config)
+
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		t.Fatalf("Expected a *strconv.NumError, got: %v", err)
+	}
+	if numErr.Num != "****2100" {
+		t.Errorf("Expected the unwrapped cause to be redacted, got: %q", numErr.Num)
+	}
+
+	var walk func(cause error)
+	walk = func(cause error) {
+		if strings.Contains(cause.Error(), "98765x432100") {
+			t.Errorf("Expected no cause to expose the secret, got: %v", cause)
+		}
+		switch unwrapper := cause.(type) {
+		case interface{ Unwrap() error }:
+			if next := unwrapper.Unwrap(); next != nil {
+				walk(next)
+			}
+		case interface{ Unwrap() []error }:
+			for _, next := range unwrapper.Unwrap() {
+				walk(next)
+			}
+		}
+	}
+	walk(err)
+}
+
+func TestRequireSpecs_ShortSecretIsRedacted(t *testing.T) {
+	err := RequireSpecsFrom(MapLookuper{"K": "a"}, RequireSpec{Key: "K", Type: "int", Secret: true})
+
+	expected := `environment variable K must be a valid int: strconv.ParseInt: parsing "****": invalid syntax`
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected %q, got %v", expected, err)
+	}
+
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) || numErr.Num != "****" {
+		t.Errorf("Expected a redacted *strconv.NumError, got: %v", numErr)
+	}
+}
@@ -0,0 +1,128 @@
+package dotenv
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// Reloader is a struct holder that can load its struct again, such as Config.
+type Reloader interface {
+	Reload() error
+}
+
+// SignalOptions provides configuration options for ReloadOnSignal
+type SignalOptions struct {
+	// Signals trigger a reload, SIGHUP when empty
+	Signals []os.Signal
+
+	// Files are the .env files to read again, the files loaded with Parse when empty.
+	// Later files override earlier ones.
+	Files []string
+
+	// Holders are reloaded in order once the environment is updated
+	Holders []Reloader
+
+	// Logger receives the changed keys and the errors of every reload, log.Default() when nil.
+	// Values are never logged, as they may be secret.
+	Logger *log.Logger
+}
+
+// SignalReloader reloads .env files when the process receives a signal.
+type SignalReloader struct {
+	watcher *Watcher
+	opts    SignalOptions
+	signals chan os.Signal
+	done    chan struct{}
+
+	stopOnce sync.Once
+}
+
+// ReloadOnSignal reads the .env files again every time the process receives one of the signals,
+// SIGHUP by default. The added and modified variables are set in the environment,
+// the removed ones are unset, then the holders are reloaded and the changes are logged.
+// Changes are computed against the content of the files when ReloadOnSignal is called.
+func ReloadOnSignal(opts SignalOptions) (*SignalReloader, error) {
+	if len(opts.Signals) == 0 {
+		opts.Signals = []os.Signal{syscall.SIGHUP}
+	}
+	if len(opts.Files) == 0 {
+		opts.Files = loadedFiles()
+	}
+	if opts.Logger == nil {
+		opts.Logger = log.Default()
+	}
+
+	if len(opts.Files) == 0 {
+		return nil, fmt.Errorf("no .env file to reload, call Parse or set Files")
+	}
+
+	watcher, err := NewWatcherWithOptions(WatcherOptions{Apply: true}, opts.Files...)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &SignalReloader{
+		watcher: watcher,
+		opts:    opts,
+		signals: make(chan os.Signal, 1),
+		done:    make(chan struct{}),
+	}
+
+	signal.Notify(r.signals, opts.Signals...)
+	go r.run()
+
+	return r, nil
+}
+
+func (r *SignalReloader) run() {
+	defer close(r.done)
+
+	for range r.signals {
+		_ = r.Reload()
+	}
+}
+
+// Reload reads the files and applies their changes as a signal does.
+func (r *SignalReloader) Reload() error {
+	files := strings.Join(r.opts.Files, ", ")
+
+	changes, err := r.watcher.Check()
+	if err != nil {
+		r.opts.Logger.Printf("dotenv: failed to reload %s: %v", files, err)
+		return err
+	}
+
+	for _, change := range changes {
+		r.opts.Logger.Printf("dotenv: %s", change)
+	}
+
+	var firstErr error
+	for _, holder := range r.opts.Holders {
+		if err = holder.Reload(); err != nil {
+			r.opts.Logger.Printf("dotenv: failed to reload config from %s: %v", files, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+
+	r.opts.Logger.Printf("dotenv: reloaded %s, %d variable(s) changed", files, len(changes))
+	return nil
+}
+
+// Stop stops listening for the signals and waits for the current reload to finish.
+func (r *SignalReloader) Stop() {
+	r.stopOnce.Do(func() {
+		signal.Stop(r.signals)
+		close(r.signals)
+	})
+	<-r.done
+}
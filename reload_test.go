@@ -0,0 +1,142 @@
+package dotenv
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type reloadConfig struct {
+	Feature bool   `env:"RELOAD_FEATURE"`
+	Token   string `env:"RELOAD_TOKEN" secret:"true"`
+}
+
+func TestSignalReloader_Reload(t *testing.T) {
+	location := filepath.Join(t.TempDir(), ".env")
+	writeWatchedFile(t, location, "RELOAD_FEATURE=off\nRELOAD_TOKEN=old-token\nRELOAD_OLD=1\n")
+
+	defer func() {
+		for _, key := range []string{"RELOAD_FEATURE", "RELOAD_TOKEN", "RELOAD_OLD"} {
+			_ = os.Unsetenv(key)
+		}
+	}()
+
+	if err := Parse(location); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	config, err := NewConfig[reloadConfig](LoadOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if files := loadedFiles(); files[len(files)-1] != location {
+		t.Errorf("Expected %s to be recorded as loaded, got: %v", location, files)
+	}
+
+	var logs bytes.Buffer
+	reloader, err := ReloadOnSignal(SignalOptions{Files: []string{location}, Holders: []Reloader{config}, Logger: log.New(&logs, "", 0)})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	defer reloader.Stop()
+
+	writeWatchedFile(t, location, "RELOAD_FEATURE=on\nRELOAD_TOKEN=new-token\n")
+	if err = reloader.Reload(); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if os.Getenv("RELOAD_FEATURE") != "on" {
+		t.Error("Expected RELOAD_FEATURE to be updated in the environment")
+	}
+	if _, exists := os.LookupEnv("RELOAD_OLD"); exists {
+		t.Error("Expected RELOAD_OLD to be unset")
+	}
+
+	if *config.Get() != (reloadConfig{Feature: true, Token: "new-token"}) {
+		t.Errorf("Expected holder to be reloaded, got: %+v", *config.Get())
+	}
+
+	output := logs.String()
+	for _, expected := range []string{"dotenv: modified RELOAD_FEATURE", "dotenv: removed RELOAD_OLD", "3 variable(s) changed"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected log to contain %q, got:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "new-token") {
+		t.Errorf("Expected values not to be logged, got:\n%s", output)
+	}
+}
+
+func TestReloadOnSignal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals cannot be sent to the current process on windows")
+	}
+
+	location := filepath.Join(t.TempDir(), ".env")
+	writeWatchedFile(t, location, "RELOAD_SIGNAL=1\n")
+	defer func() { _ = os.Unsetenv("RELOAD_SIGNAL") }()
+
+	config, err := NewConfig[struct {
+		Value int `env:"RELOAD_SIGNAL"`
+	}](LoadOptions{Lookuper: MapLookuper{}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	reloaded := make(chan struct{}, 1)
+	holder := reloaderFunc(func() error {
+		reloaded <- struct{}{}
+		return config.Reload()
+	})
+
+	var logs bytes.Buffer
+	reloader, err := ReloadOnSignal(SignalOptions{
+		Files:   []string{location},
+		Holders: []Reloader{holder},
+		Logger:  log.New(&logs, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	writeWatchedFile(t, location, "RELOAD_SIGNAL=2\n")
+
+	process, _ := os.FindProcess(os.Getpid())
+	if err = process.Signal(syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-reloaded:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a reload after the signal")
+	}
+	reloader.Stop()
+
+	if os.Getenv("RELOAD_SIGNAL") != "2" {
+		t.Errorf("Expected RELOAD_SIGNAL to be 2, got %q", os.Getenv("RELOAD_SIGNAL"))
+	}
+	if !strings.Contains(logs.String(), "dotenv: modified RELOAD_SIGNAL") {
+		t.Errorf("Expected the change to be logged, got:\n%s", logs.String())
+	}
+}
+
+func TestReloadOnSignal_MissingFile(t *testing.T) {
+	if _, err := ReloadOnSignal(SignalOptions{Files: []string{"test/missing.env"}}); err == nil {
+		t.Error("Expected error for a missing file, got nil")
+	}
+}
+
+// reloaderFunc adapts a function to the Reloader interface.
+type reloaderFunc func() error
+
+func (f reloaderFunc) Reload() error {
+	return f()
+}
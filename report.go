@@ -0,0 +1,240 @@
+package dotenv
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// Origin tells where the value of a variable comes from.
+type Origin int
+
+const (
+	// OriginUnset means that the variable is not set and has no default value.
+	OriginUnset Origin = iota
+	// OriginEnv means that the value comes from the environment, or from LoadOptions.Lookuper.
+	OriginEnv
+	// OriginFile means that the value comes from a .env file, or from a file named by a _FILE variable.
+	OriginFile
+	// OriginDefault means that the value comes from the default tag.
+	OriginDefault
+)
+
+// String returns a short description of the origin.
+func (o Origin) String() string {
+	switch o {
+	case OriginEnv:
+		return "env"
+	case OriginFile:
+		return "file"
+	case OriginDefault:
+		return "default"
+	default:
+		return "unset"
+	}
+}
+
+// Source describes where the value of a variable comes from.
+type Source struct {
+	// Key is the name of the variable
+	Key string
+	// Field is the Go path of the struct field, empty for the file loader
+	Field string
+	// Value is the resolved value, redacted when Secret is true
+	Value string
+	// Secret reports whether the field is tagged secret:"true"
+	Secret bool
+	// Origin tells where the value comes from
+	Origin Origin
+	// File and Line locate the definition when Origin is OriginFile.
+	// Line is 0 when the whole file is the value.
+	File string
+	Line int
+	// References are the variables substituted into the value, in order
+	References []string
+}
+
+// Location describes the origin of the value, e.g. ".env:3 (via BASE_URL)".
+func (s Source) Location() string {
+	var location string
+	switch {
+	case s.Origin == OriginFile && s.Line > 0:
+		location = fmt.Sprintf("%s:%d", s.File, s.Line)
+	case s.Origin == OriginFile:
+		location = s.File
+	default:
+		location = s.Origin.String()
+	}
+
+	if len(s.References) > 0 {
+		location += " (via " + strings.Join(s.References, ", ") + ")"
+	}
+
+	return location
+}
+
+// Report lists where the value of every variable comes from, in loading order.
+type Report struct {
+	Sources []Source
+}
+
+// Get returns the source of the variable named by the key.
+func (r *Report) Get(key string) (Source, bool) {
+	for _, source := range r.Sources {
+		if source.Key == key {
+			return source, true
+		}
+	}
+	return Source{}, false
+}
+
+// String returns the report as a table of keys, values and locations.
+func (r *Report) String() string {
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprint(writer, "KEY\tVALUE\tSOURCE", linebreak())
+	for _, source := range r.Sources {
+		_, _ = fmt.Fprintf(writer, "%s\t%q\t%s%s", source.Key, source.Value, source.Location(), linebreak())
+	}
+	_ = writer.Flush()
+
+	return builder.String()
+}
+
+// ParseWithReport is Parse, also returning where every variable of the file comes from.
+func ParseWithReport(location string) (*Report, error) {
+	report := &Report{}
+
+	err := parseFile(location, os.LookupEnv, func(key, value string, origin fileOrigin) error {
+		report.Sources = append(report.Sources, Source{
+			Key:        key,
+			Value:      value,
+			Origin:     OriginFile,
+			File:       origin.file,
+			Line:       origin.line,
+			References: origin.references,
+		})
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+		recordOrigin(key, origin)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recordFile(location)
+	return report, nil
+}
+
+// fileOrigin locates the definition of a variable in a .env file.
+type fileOrigin struct {
+	file       string
+	line       int
+	value      string
+	references []string
+}
+
+// parsedOrigins remembers the variables set by Parse, so the struct loader can report
+// that an environment variable comes from a .env file.
+var parsedOrigins = struct {
+	sync.RWMutex
+	values map[string]fileOrigin
+}{values: map[string]fileOrigin{}}
+
+// recordOrigin remembers that Parse has set a variable.
+func recordOrigin(key string, origin fileOrigin) {
+	parsedOrigins.Lock()
+	defer parsedOrigins.Unlock()
+	parsedOrigins.values[key] = origin
+}
+
+// parsedFiles lists the files loaded by Parse, in the order they were first loaded.
+var parsedFiles = struct {
+	sync.Mutex
+	locations []string
+}{}
+
+// recordFile remembers that Parse has loaded a file.
+func recordFile(location string) {
+	parsedFiles.Lock()
+	defer parsedFiles.Unlock()
+
+	for _, known := range parsedFiles.locations {
+		if known == location {
+			return
+		}
+	}
+	parsedFiles.locations = append(parsedFiles.locations, location)
+}
+
+// loadedFiles returns the files loaded by Parse.
+func loadedFiles() []string {
+	parsedFiles.Lock()
+	defer parsedFiles.Unlock()
+
+	return append([]string(nil), parsedFiles.locations...)
+}
+
+// originLookuper is implemented by the Lookupers knowing which variables come from a .env file.
+type originLookuper interface {
+	origin(key string) (fileOrigin, bool)
+}
+
+// lookupOrigin returns the .env file definition of a variable found by lookuper, if known.
+func lookupOrigin(lookuper Lookuper, key string) (fileOrigin, bool) {
+	if l, ok := lookuper.(originLookuper); ok {
+		return l.origin(key)
+	}
+	return fileOrigin{}, false
+}
+
+// origin reports the variables set by Parse, as long as they have not been changed since.
+func (OSLookuper) origin(key string) (fileOrigin, bool) {
+	parsedOrigins.RLock()
+	origin, exists := parsedOrigins.values[key]
+	parsedOrigins.RUnlock()
+
+	if !exists {
+		return fileOrigin{}, false
+	}
+
+	if value, isSet := os.LookupEnv(key); !isSet || value != origin.value {
+		return fileOrigin{}, false
+	}
+
+	return origin, true
+}
+
+func (p PrefixLookuper) origin(key string) (fileOrigin, bool) {
+	return lookupOrigin(p.Lookuper, p.Prefix+key)
+}
+
+func (m MultiLookuper) origin(key string) (fileOrigin, bool) {
+	for _, lookuper := range m {
+		if _, exists := lookuper.Lookup(key); exists {
+			return lookupOrigin(lookuper, key)
+		}
+	}
+	return fileOrigin{}, false
+}
+
+// fileLookuper looks up the variables read from a .env file.
+type fileLookuper struct {
+	values  map[string]string
+	origins map[string]fileOrigin
+}
+
+func (f fileLookuper) Lookup(key string) (string, bool) {
+	value, exists := f.values[key]
+	return value, exists
+}
+
+func (f fileLookuper) origin(key string) (fileOrigin, bool) {
+	origin, exists := f.origins[key]
+	return origin, exists
+}
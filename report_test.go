@@ -0,0 +1,157 @@
+package dotenv
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type reportConfig struct {
+	URL     string `env:"REPORT_URL"`
+	Port    int    `env:"REPORT_PORT"`
+	Host    string `env:"REPORT_HOST"`
+	Timeout string `env:"REPORT_TIMEOUT" default:"30s"`
+	Cache   string `env:"REPORT_CACHE" default:"${REPORT_HOST}/cache" expand:"true"`
+	Token   string `env:"REPORT_TOKEN" secret:"true"`
+	Missing string `env:"REPORT_MISSING"`
+}
+
+func cleanupReport() {
+	for _, key := range []string{"REPORT_BASE", "REPORT_URL", "REPORT_PORT", "REPORT_HOST", "REPORT_TOKEN"} {
+		_ = os.Unsetenv(key)
+	}
+}
+
+func TestParseWithReport(t *testing.T) {
+	cleanupReport()
+	t.Cleanup(cleanupReport)
+
+	report, err := ParseWithReport("test/test_report.env")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source, exists := report.Get("REPORT_URL")
+	if !exists {
+		t.Fatal("REPORT_URL should be in the report")
+	}
+
+	expected := Source{
+		Key:        "REPORT_URL",
+		Value:      "https://api.example.com/v1",
+		Origin:     OriginFile,
+		File:       "test/test_report.env",
+		Line:       4,
+		References: []string{"REPORT_BASE"},
+	}
+
+	if !reflect.DeepEqual(source, expected) {
+		t.Errorf("Get(REPORT_URL) = %+v, want %+v", source, expected)
+	}
+
+	if source.Location() != "test/test_report.env:4 (via REPORT_BASE)" {
+		t.Errorf("Unexpected location: %s", source.Location())
+	}
+
+	if os.Getenv("REPORT_URL") != "https://api.example.com/v1" {
+		t.Errorf("ParseWithReport should set the environment variables")
+	}
+}
+
+func TestParseWithReport_RecordsOrigins(t *testing.T) {
+	cleanupReport()
+	t.Cleanup(cleanupReport)
+
+	// Forget the origins recorded by other tests
+	parsedOrigins.Lock()
+	for _, key := range []string{"REPORT_BASE", "REPORT_URL", "REPORT_PORT"} {
+		delete(parsedOrigins.values, key)
+	}
+	parsedOrigins.Unlock()
+
+	if _, err := ParseWithReport("test/test_report.env"); err != nil {
+		t.Fatal(err)
+	}
+
+	config := struct {
+		Port int `env:"REPORT_PORT"`
+	}{}
+	report := &Report{}
+	if err := LoadStructWithOptions(&config, LoadOptions{Report: report}); err != nil {
+		t.Fatal(err)
+	}
+
+	source, _ := report.Get("REPORT_PORT")
+	if source.Origin != OriginFile || source.Location() != "test/test_report.env:5" {
+		t.Errorf("Expected REPORT_PORT to come from test/test_report.env:5, got %s (%s)", source.Location(), source.Origin)
+	}
+}
+
+func TestLoadStructWithOptions_Report(t *testing.T) {
+	cleanupReport()
+	t.Cleanup(cleanupReport)
+
+	if err := Parse("test/test_report.env"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Overridden after parsing, so it comes from the environment
+	_ = os.Setenv("REPORT_PORT", "9090")
+	_ = os.Setenv("REPORT_HOST", "localhost")
+	_ = os.Setenv("REPORT_TOKEN", "tok_abcdefgh1234")
+
+	report := &Report{}
+	if err := LoadStructWithOptions(&reportConfig{}, LoadOptions{Report: report}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key      string
+		value    string
+		location string
+	}{
+		{"REPORT_URL", "https://api.example.com/v1", "test/test_report.env:4 (via REPORT_BASE)"},
+		{"REPORT_PORT", "9090", "env"},
+		{"REPORT_HOST", "localhost", "env"},
+		{"REPORT_TIMEOUT", "30s", "default"},
+		{"REPORT_CACHE", "localhost/cache", "default (via REPORT_HOST)"},
+		{"REPORT_TOKEN", "****1234", "env"},
+		{"REPORT_MISSING", "", "unset"},
+	}
+
+	if len(report.Sources) != len(tests) {
+		t.Fatalf("Expected %d sources, got %d", len(tests), len(report.Sources))
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			source, exists := report.Get(tt.key)
+			if !exists {
+				t.Fatalf("%s should be in the report", tt.key)
+			}
+			if source.Value != tt.value || source.Location() != tt.location {
+				t.Errorf("Got %q from %q, want %q from %q", source.Value, source.Location(), tt.value, tt.location)
+			}
+		})
+	}
+
+	table := report.String()
+	if !strings.HasPrefix(table, "KEY") || !strings.Contains(table, "test/test_report.env:4 (via REPORT_BASE)") {
+		t.Errorf("Unexpected table:\n%s", table)
+	}
+}
+
+func TestUnmarshalFileWithOptions_Report(t *testing.T) {
+	cleanupReport()
+
+	report := &Report{}
+	if err := UnmarshalFileWithOptions("test/test_report.env", &reportConfig{}, LoadOptions{Report: report}); err != nil {
+		t.Fatal(err)
+	}
+
+	source, _ := report.Get("REPORT_PORT")
+	if source.Location() != "test/test_report.env:5" {
+		t.Errorf("Unexpected location for REPORT_PORT: %s", source.Location())
+	}
+}
@@ -2,26 +2,331 @@ package dotenv
 
 import (
 	"fmt"
-	"os"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Require check if the given keys are set in the environment variables.
+// The returned error is a *RequireReport matching ErrRequired, with one *FieldError per missing key.
 func Require(keys ...string) error {
-	var required []string
+	return RequireFrom(OSLookuper{}, keys...)
+}
+
+// RequireFrom check if the given keys are set in the given Lookuper.
+func RequireFrom(lookuper Lookuper, keys ...string) error {
+	var required []*FieldError
 
 	for _, key := range keys {
-		if os.Getenv(key) == "" {
-			required = append(required, key)
+		if value, _ := lookuper.Lookup(key); value == "" {
+			required = append(required, &FieldError{
+				Key:  key,
+				Kind: ErrRequired,
+				Err:  fmt.Errorf("required environment variable %s is not set", key),
+			})
 		}
 	}
 
 	if len(required) > 0 {
-		return fmt.Errorf(
-			"the following environment variables are required: %s",
-			strings.Join(required, ", "),
-		)
+		return &RequireReport{Errors: required}
+	}
+
+	return nil
+}
+
+// RequireSpec describes the checks of a single variable for RequireSpecs.
+type RequireSpec struct {
+	// Key is the name of the variable
+	Key string
+
+	// Optional accepts an unset or empty variable, the other checks only apply when it is set
+	Optional bool
+
+	// Type is the type the value must be parseable as:
+	// "int", "uint", "float", "bool", "duration", "url", "ip" or "bytes"
+	Type string
+
+	// Pattern is a regular expression the value must match
+	Pattern string
+
+	// OneOf lists the allowed values
+	OneOf []string
+
+	// Bools lists the spellings accepted by the "bool" Type, DefaultBoolVocabulary when zero
+	Bools BoolVocabulary
+
+	// Secret redacts the value in the report
+	Secret bool
+}
+
+// specTypes checks that a value is parseable as the Type of a RequireSpec.
+var specTypes = map[string]func(s RequireSpec, value string) error{
+	"int": func(_ RequireSpec, value string) error {
+		_, err := strconv.ParseInt(value, 10, 64)
+		return err
+	},
+	"uint": func(_ RequireSpec, value string) error {
+		_, err := strconv.ParseUint(value, 10, 64)
+		return err
+	},
+	"float": func(_ RequireSpec, value string) error {
+		_, err := strconv.ParseFloat(value, 64)
+		return err
+	},
+	"bool": func(s RequireSpec, value string) error {
+		_, err := s.Bools.Parse(value)
+		return err
+	},
+	"duration": func(_ RequireSpec, value string) error {
+		_, err := time.ParseDuration(value)
+		return err
+	},
+	"url": func(_ RequireSpec, value string) error {
+		return validateURL(reflect.ValueOf(value))
+	},
+	"ip": func(_ RequireSpec, value string) error {
+		_, err := parseIP(value)
+		return err
+	},
+	"bytes": func(_ RequireSpec, value string) error {
+		_, err := parseBytes(value)
+		return err
+	},
+}
+
+// RequireSpecs checks the environment variables against the given specs.
+// Every spec is checked, and the returned error is a *RequireReport listing each failing key.
+func RequireSpecs(specs ...RequireSpec) error {
+	return RequireSpecsFrom(OSLookuper{}, specs...)
+}
+
+// RequireSpecsFrom checks the variables of the given Lookuper against the given specs.
+func RequireSpecsFrom(lookuper Lookuper, specs ...RequireSpec) error {
+	report := &RequireReport{}
+
+	for _, spec := range specs {
+		if err := spec.check(lookuper); err != nil {
+			report.Errors = append(report.Errors, err)
+		}
+	}
+
+	if len(report.Errors) > 0 {
+		return report
+	}
+
+	return nil
+}
+
+// check returns the first failure of the spec, or nil.
+func (s RequireSpec) check(lookuper Lookuper) *FieldError {
+	value, _ := lookuper.Lookup(s.Key)
+	if value == "" {
+		if s.Optional {
+			return nil
+		}
+		return &FieldError{
+			Key:    s.Key,
+			Secret: s.Secret,
+			Kind:   ErrRequired,
+			Err:    fmt.Errorf("required environment variable %s is not set", s.Key),
+		}
+	}
+
+	fail := func(kind error, err error) *FieldError {
+		fieldErr := &FieldError{Key: s.Key, Value: value, Secret: s.Secret, Kind: kind, Err: err}
+		if s.Secret {
+			fieldErr.Value = Redact(value)
+			fieldErr.Err = redactError(err, value)
+		}
+		return fieldErr
+	}
+
+	if s.Type != "" {
+		parse, exists := specTypes[s.Type]
+		if !exists {
+			return fail(ErrUnsupportedType, fmt.Errorf("%w %q for environment variable %s", ErrUnsupportedType, s.Type, s.Key))
+		}
+		if err := parse(s, value); err != nil {
+			return fail(ErrParse, fmt.Errorf("environment variable %s must be a valid %s: %w", s.Key, s.Type, err))
+		}
+	}
+
+	if s.Pattern != "" {
+		validator, err := regexpValidator(s.Pattern)
+		if err == nil {
+			err = validator(reflect.ValueOf(value))
+		}
+		if err != nil {
+			return fail(ErrValidation, fmt.Errorf("environment variable %s is invalid: %w", s.Key, err))
+		}
+	}
+
+	if len(s.OneOf) > 0 {
+		validator, err := oneOfValidator(strings.Join(s.OneOf, " "))
+		if err == nil {
+			err = validator(reflect.ValueOf(value))
+		}
+		if err != nil {
+			return fail(ErrValidation, fmt.Errorf("environment variable %s is invalid: %w", s.Key, err))
+		}
+	}
+
+	return nil
+}
+
+// RequireReport lists every variable failing a Require or RequireSpecs call, one *FieldError per key.
+type RequireReport struct {
+	Errors []*FieldError
+}
+
+// Keys returns the failing keys, in the order of the specs.
+func (r *RequireReport) Keys() []string {
+	keys := make([]string, len(r.Errors))
+	for i, err := range r.Errors {
+		keys[i] = err.Key
+	}
+	return keys
+}
+
+// Error lists the keys on one line when they are all missing,
+// e.g. "the following environment variables are required: API_KEY, SECRET".
+// Otherwise it returns the message of the only failure, or a report listing every key when there are several.
+func (r *RequireReport) Error() string {
+	missing := true
+	for _, err := range r.Errors {
+		missing = missing && err.Kind == ErrRequired
+	}
+	if missing {
+		return fmt.Sprintf("the following environment variables are required: %s", strings.Join(r.Keys(), ", "))
+	}
+
+	header := fmt.Sprintf("%d environment variables are invalid:", len(r.Errors))
+	return reportMessage(header, r.Errors, func(err *FieldError) string {
+		return err.Err.Error()
+	})
+}
+
+// Unwrap exposes every FieldError to errors.Is and errors.As.
+func (r *RequireReport) Unwrap() []error {
+	return unwrapAll(r.Errors)
+}
+
+// GroupRule tells how many keys of a RequireGroup must be set.
+type GroupRule int
+
+const (
+	// AtLeastOne requires one or more keys of the group to be set.
+	AtLeastOne GroupRule = iota
+	// ExactlyOne requires a single key of the group to be set.
+	ExactlyOne
+	// AtMostOne forbids setting more than one key of the group.
+	AtMostOne
+)
+
+// RequireGroup is a set of keys checked together by RequireGroups.
+type RequireGroup struct {
+	Rule GroupRule
+	Keys []string
+}
+
+// RequireAny checks that at least one of the given keys is set in the environment variables.
+func RequireAny(keys ...string) error {
+	return RequireGroups(RequireGroup{Rule: AtLeastOne, Keys: keys})
+}
+
+// RequireOneOf checks that exactly one of the given keys is set in the environment variables.
+func RequireOneOf(keys ...string) error {
+	return RequireGroups(RequireGroup{Rule: ExactlyOne, Keys: keys})
+}
+
+// RequireExclusive checks that at most one of the given keys is set in the environment variables.
+func RequireExclusive(keys ...string) error {
+	return RequireGroups(RequireGroup{Rule: AtMostOne, Keys: keys})
+}
+
+// RequireGroups checks every group against the environment variables.
+// The returned error is a *GroupReport listing each failing group.
+func RequireGroups(groups ...RequireGroup) error {
+	return RequireGroupsFrom(OSLookuper{}, groups...)
+}
+
+// RequireGroupsFrom checks every group against the variables of the given Lookuper.
+// As for Require, a key set to an empty value counts as unset.
+func RequireGroupsFrom(lookuper Lookuper, groups ...RequireGroup) error {
+	report := &GroupReport{}
+
+	for _, group := range groups {
+		var set []string
+		for _, key := range group.Keys {
+			if value, _ := lookuper.Lookup(key); value != "" {
+				set = append(set, key)
+			}
+		}
+
+		var kind error
+		switch {
+		case len(set) == 0 && group.Rule != AtMostOne:
+			kind = ErrRequired
+		case len(set) > 1 && group.Rule != AtLeastOne:
+			kind = ErrConflict
+		default:
+			continue
+		}
+
+		report.Errors = append(report.Errors, &GroupError{Rule: group.Rule, Keys: group.Keys, Set: set, Kind: kind})
+	}
+
+	if len(report.Errors) > 0 {
+		return report
 	}
 
 	return nil
 }
+
+// GroupError describes why a RequireGroup failed.
+type GroupError struct {
+	// Rule is the rule of the group
+	Rule GroupRule
+	// Keys are the keys of the group
+	Keys []string
+	// Set are the keys of the group that are set
+	Set []string
+	// Kind is ErrRequired when too few keys are set, ErrConflict when too many are
+	Kind error
+}
+
+func (e *GroupError) Error() string {
+	keys := strings.Join(e.Keys, ", ")
+
+	switch {
+	case e.Kind == ErrRequired && e.Rule == ExactlyOne:
+		return fmt.Sprintf("exactly one of the environment variables %s is required", keys)
+	case e.Kind == ErrRequired:
+		return fmt.Sprintf("at least one of the environment variables %s is required", keys)
+	case e.Rule == ExactlyOne:
+		return fmt.Sprintf("exactly one of the environment variables %s must be set, got %s", keys, strings.Join(e.Set, ", "))
+	default:
+		return fmt.Sprintf("at most one of the environment variables %s can be set, got %s", keys, strings.Join(e.Set, ", "))
+	}
+}
+
+func (e *GroupError) Unwrap() error {
+	return e.Kind
+}
+
+// GroupReport lists every group failing a RequireGroups call.
+type GroupReport struct {
+	Errors []*GroupError
+}
+
+// Error returns the message of the only failure, or a report listing every group when there are several.
+func (r *GroupReport) Error() string {
+	header := fmt.Sprintf("%d environment variable groups are invalid:", len(r.Errors))
+	return reportMessage(header, r.Errors, (*GroupError).Error)
+}
+
+// Unwrap exposes every GroupError to errors.Is and errors.As.
+func (r *GroupReport) Unwrap() []error {
+	return unwrapAll(r.Errors)
+}
@@ -1,6 +1,11 @@
 package dotenv
 
-import "testing"
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
 
 func TestRequireOK(t *testing.T) {
 	if err := Parse("test/.env"); err != nil {
@@ -30,3 +35,184 @@ func TestRequireNOK(t *testing.T) {
 		t.Errorf("INVALID is not define but return nil")
 	}
 }
+
+func TestRequireErrRequired(t *testing.T) {
+	err := Require("INVALID", "ALSO_INVALID")
+	if !errors.Is(err, ErrRequired) {
+		t.Fatalf("expected ErrRequired, got %v", err)
+	}
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Key != "INVALID" {
+		t.Errorf("expected a *FieldError for INVALID, got %v", fieldErr)
+	}
+
+	if err.Error() != "the following environment variables are required: INVALID, ALSO_INVALID" {
+		t.Errorf("unexpected error message: %s", err)
+	}
+
+	var report *RequireReport
+	if !errors.As(err, &report) || len(report.Keys()) != 2 {
+		t.Errorf("expected a *RequireReport with 2 keys, got %v", err)
+	}
+}
+
+func TestRequireSpecsFrom(t *testing.T) {
+	lookuper := MapLookuper{
+		"PORT":       "8080",
+		"BAD_PORT":   "80x",
+		"DEBUG":      "yes",
+		"API_URL":    "https://api.example.com",
+		"BAD_URL":    "example.com",
+		"LOG_LEVEL":  "info",
+		"BAD_LEVEL":  "verbose",
+		"REGION":     "eu-west-1",
+		"BAD_REGION": "mars",
+		"EMPTY":      "",
+	}
+
+	tests := []struct {
+		spec RequireSpec
+		kind error
+	}{
+		{RequireSpec{Key: "PORT", Type: "int"}, nil},
+		{RequireSpec{Key: "BAD_PORT", Type: "int"}, ErrParse},
+		{RequireSpec{Key: "DEBUG", Type: "bool"}, nil},
+		{RequireSpec{Key: "DEBUG", Type: "bool", Bools: BoolVocabulary{Strict: true}}, ErrParse},
+		{RequireSpec{Key: "DEBUG", Type: "bool", Bools: BoolVocabulary{True: []string{"yes"}}}, nil},
+		{RequireSpec{Key: "API_URL", Type: "url"}, nil},
+		{RequireSpec{Key: "BAD_URL", Type: "url"}, ErrParse},
+		{RequireSpec{Key: "LOG_LEVEL", OneOf: []string{"debug", "info"}}, nil},
+		{RequireSpec{Key: "BAD_LEVEL", OneOf: []string{"debug", "info"}}, ErrValidation},
+		{RequireSpec{Key: "REGION", Pattern: `^[a-z]+-[a-z]+-\d$`}, nil},
+		{RequireSpec{Key: "BAD_REGION", Pattern: `^[a-z]+-[a-z]+-\d$`}, ErrValidation},
+		{RequireSpec{Key: "EMPTY"}, ErrRequired},
+		{RequireSpec{Key: "MISSING"}, ErrRequired},
+		{RequireSpec{Key: "MISSING", Optional: true, Type: "int"}, nil},
+		{RequireSpec{Key: "PORT", Type: "complex"}, ErrUnsupportedType},
+	}
+
+	for _, tt := range tests {
+		err := RequireSpecsFrom(lookuper, tt.spec)
+		if tt.kind == nil {
+			if err != nil {
+				t.Errorf("%+v: expected no error, got: %v", tt.spec, err)
+			}
+			continue
+		}
+		if !errors.Is(err, tt.kind) {
+			t.Errorf("%+v: expected %v, got: %v", tt.spec, tt.kind, err)
+		}
+	}
+}
+
+func TestRequireSpecsFrom_Report(t *testing.T) {
+	lookuper := MapLookuper{"PORT": "80x", "LOG_LEVEL": "info", "TOKEN": "sk_live_1234567890ab12"}
+
+	err := RequireSpecsFrom(lookuper,
+		RequireSpec{Key: "PORT", Type: "int"},
+		RequireSpec{Key: "LOG_LEVEL", OneOf: []string{"debug", "info"}},
+		RequireSpec{Key: "DATABASE_URL"},
+		RequireSpec{Key: "TOKEN", Type: "int", Secret: true},
+	)
+
+	var report *RequireReport
+	if !errors.As(err, &report) {
+		t.Fatalf("expected a *RequireReport, got %v", err)
+	}
+
+	if keys := report.Keys(); len(keys) != 3 || keys[0] != "PORT" || keys[1] != "DATABASE_URL" || keys[2] != "TOKEN" {
+		t.Errorf("unexpected failing keys: %v", keys)
+	}
+
+	expected := "3 environment variables are invalid:" + linebreak() +
+		`  - environment variable PORT must be a valid int: strconv.ParseInt: parsing "80x": invalid syntax` + linebreak() +
+		"  - required environment variable DATABASE_URL is not set" + linebreak() +
+		`  - environment variable TOKEN must be a valid int: strconv.ParseInt: parsing "****ab12": invalid syntax`
+	if err.Error() != expected {
+		t.Errorf("unexpected report:\n%s\nwant:\n%s", err, expected)
+	}
+
+	if report.Errors[2].Value != "****ab12" {
+		t.Errorf("expected the secret value to be redacted, got %s", report.Errors[2].Value)
+	}
+}
+
+func TestRequireGroupsFrom(t *testing.T) {
+	lookuper := MapLookuper{"DATABASE_URL": "postgres://db", "AWS_PROFILE": "dev", "AWS_ACCESS_KEY_ID": "AKIA", "EMPTY": ""}
+
+	tests := []struct {
+		group    RequireGroup
+		kind     error
+		expected string
+	}{
+		{RequireGroup{Rule: AtLeastOne, Keys: []string{"DATABASE_URL", "DB_HOST"}}, nil, ""},
+		{RequireGroup{Rule: AtLeastOne, Keys: []string{"DB_HOST", "EMPTY"}}, ErrRequired,
+			"at least one of the environment variables DB_HOST, EMPTY is required"},
+		{RequireGroup{Rule: ExactlyOne, Keys: []string{"DATABASE_URL", "DB_HOST"}}, nil, ""},
+		{RequireGroup{Rule: ExactlyOne, Keys: []string{"DB_HOST", "DB_SOCKET"}}, ErrRequired,
+			"exactly one of the environment variables DB_HOST, DB_SOCKET is required"},
+		{RequireGroup{Rule: ExactlyOne, Keys: []string{"AWS_PROFILE", "AWS_ACCESS_KEY_ID"}}, ErrConflict,
+			"exactly one of the environment variables AWS_PROFILE, AWS_ACCESS_KEY_ID must be set, got AWS_PROFILE, AWS_ACCESS_KEY_ID"},
+		{RequireGroup{Rule: AtMostOne, Keys: []string{"DB_HOST", "EMPTY"}}, nil, ""},
+		{RequireGroup{Rule: AtMostOne, Keys: []string{"AWS_PROFILE", "AWS_ACCESS_KEY_ID", "DB_HOST"}}, ErrConflict,
+			"at most one of the environment variables AWS_PROFILE, AWS_ACCESS_KEY_ID, DB_HOST can be set, got AWS_PROFILE, AWS_ACCESS_KEY_ID"},
+	}
+
+	for _, tt := range tests {
+		err := RequireGroupsFrom(lookuper, tt.group)
+		if tt.kind == nil {
+			if err != nil {
+				t.Errorf("%v: expected no error, got: %v", tt.group.Keys, err)
+			}
+			continue
+		}
+
+		if !errors.Is(err, tt.kind) {
+			t.Errorf("%v: expected %v, got: %v", tt.group.Keys, tt.kind, err)
+		}
+		if err != nil && err.Error() != tt.expected {
+			t.Errorf("%v: expected %q, got %q", tt.group.Keys, tt.expected, err)
+		}
+	}
+}
+
+func TestRequireGroups_Report(t *testing.T) {
+	_ = os.Setenv("GROUP_A", "a")
+	_ = os.Setenv("GROUP_B", "b")
+	defer func() {
+		_ = os.Unsetenv("GROUP_A")
+		_ = os.Unsetenv("GROUP_B")
+	}()
+
+	if err := RequireAny("GROUP_A", "GROUP_MISSING"); err != nil {
+		t.Errorf("RequireAny: expected no error, got: %v", err)
+	}
+
+	if err := RequireOneOf("GROUP_A", "GROUP_B"); !errors.Is(err, ErrConflict) {
+		t.Errorf("RequireOneOf: expected ErrConflict, got: %v", err)
+	}
+
+	if err := RequireExclusive("GROUP_MISSING", "GROUP_OTHER"); err != nil {
+		t.Errorf("RequireExclusive: expected no error, got: %v", err)
+	}
+
+	err := RequireGroups(
+		RequireGroup{Rule: AtLeastOne, Keys: []string{"GROUP_MISSING", "GROUP_OTHER"}},
+		RequireGroup{Rule: AtLeastOne, Keys: []string{"GROUP_A"}},
+		RequireGroup{Rule: AtMostOne, Keys: []string{"GROUP_A", "GROUP_B"}},
+	)
+
+	var report *GroupReport
+	if !errors.As(err, &report) || len(report.Errors) != 2 {
+		t.Fatalf("expected a *GroupReport with 2 errors, got %v", err)
+	}
+
+	if report.Errors[0].Kind != ErrRequired || report.Errors[1].Kind != ErrConflict {
+		t.Errorf("unexpected kinds: %v, %v", report.Errors[0].Kind, report.Errors[1].Kind)
+	}
+
+	if !strings.HasPrefix(err.Error(), "2 environment variable groups are invalid:") {
+		t.Errorf("unexpected report: %s", err)
+	}
+}
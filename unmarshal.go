@@ -0,0 +1,25 @@
+package dotenv
+
+// UnmarshalFile parses the .env file located at the given location and loads its variables into a struct,
+// using the same tags as LoadStruct. Unlike Parse followed by LoadStruct, the environment variables
+// are never set, so the values are not inherited by child processes.
+func UnmarshalFile(location string, data interface{}) error {
+	return UnmarshalFileWithOptions(location, data, LoadOptions{})
+}
+
+// UnmarshalFileWithOptions is UnmarshalFile with additional options.
+// When opts.Lookuper is set, it is used for the variables missing from the file.
+func UnmarshalFileWithOptions(location string, data interface{}, opts LoadOptions) error {
+	file, err := readFile(location)
+	if err != nil {
+		return err
+	}
+
+	if opts.Lookuper != nil {
+		opts.Lookuper = MultiLookuper{file, opts.Lookuper}
+	} else {
+		opts.Lookuper = file
+	}
+
+	return LoadStructWithOptions(data, opts)
+}
@@ -0,0 +1,72 @@
+package dotenv
+
+import (
+	"os"
+	"testing"
+)
+
+type unmarshalConfig struct {
+	Host     string `env:"UNMARSHAL_HOST"`
+	Port     int    `env:"UNMARSHAL_PORT"`
+	URL      string `env:"UNMARSHAL_URL"`
+	Password string `env:"UNMARSHAL_PASSWORD" required:"true"`
+	Name     string `env:"UNMARSHAL_NAME" default:"app"`
+}
+
+func TestUnmarshalFile(t *testing.T) {
+	_ = os.Setenv("UNMARSHAL_NAME", "from_env")
+	defer func() { _ = os.Unsetenv("UNMARSHAL_NAME") }()
+
+	var config unmarshalConfig
+	if err := UnmarshalFile("test/test_unmarshal.env", &config); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := unmarshalConfig{
+		Host:     "db.local",
+		Port:     5433,
+		URL:      "postgres://db.local:5433/app",
+		Password: "s3cret",
+		Name:     "app",
+	}
+
+	if config != expected {
+		t.Errorf("Expected %+v, got %+v", expected, config)
+	}
+
+	// The environment is left untouched
+	for _, key := range []string{"UNMARSHAL_HOST", "UNMARSHAL_PORT", "UNMARSHAL_URL", "UNMARSHAL_PASSWORD"} {
+		if _, exists := os.LookupEnv(key); exists {
+			t.Errorf("Expected %s not to be set in the environment", key)
+		}
+	}
+}
+
+func TestUnmarshalFileWithOptions_Lookuper(t *testing.T) {
+	var config unmarshalConfig
+
+	opts := LoadOptions{Lookuper: MapLookuper{"UNMARSHAL_NAME": "from_lookuper", "UNMARSHAL_HOST": "ignored"}}
+	if err := UnmarshalFileWithOptions("test/test_unmarshal.env", &config, opts); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.Host != "db.local" {
+		t.Errorf("Expected the file to take precedence, got: %s", config.Host)
+	}
+
+	if config.Name != "from_lookuper" {
+		t.Errorf("Expected Name to be read from the lookuper, got: %s", config.Name)
+	}
+}
+
+func TestUnmarshalFileErrors(t *testing.T) {
+	var config unmarshalConfig
+
+	if err := UnmarshalFile("test/not-exist/.env", &config); err == nil {
+		t.Error("Expected error for missing file, got nil")
+	}
+
+	if err := UnmarshalFile("test/.env", &config); err == nil {
+		t.Error("Expected error for missing required variable, got nil")
+	}
+}
@@ -0,0 +1,21 @@
+package dotenv
+
+import (
+	"os"
+	"strings"
+)
+
+// defaultFileSuffix is appended to a key to find the file holding its value, e.g. DB_PASSWORD_FILE.
+const defaultFileSuffix = "_FILE"
+
+// readValueFile reads a value from a file, such as a Docker or Kubernetes secret.
+// A single trailing newline is trimmed.
+func readValueFile(location string) (string, error) {
+	content, err := os.ReadFile(location)
+	if err != nil {
+		return "", err
+	}
+
+	value := strings.TrimSuffix(string(content), "\n")
+	return strings.TrimSuffix(value, "\r"), nil
+}
@@ -0,0 +1,309 @@
+package dotenv
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// validatorFactory builds a Validator from the argument given in the validator tag, e.g. "1" for "min=1".
+type validatorFactory func(arg string) (Validator, error)
+
+// builtinValidators are the validators available from the validator tag without any registration.
+var builtinValidators = map[string]validatorFactory{
+	"min":         minValidator,
+	"max":         maxValidator,
+	"oneof":       oneOfValidator,
+	"regexp":      regexpValidator,
+	"url":         noArg(validateURL),
+	"hostport":    noArg(validateHostPort),
+	"email":       noArg(validateEmail),
+	"port":        noArg(validatePort),
+	"nonempty":    noArg(validateNonEmpty),
+	"file_exists": noArg(validateFileExists),
+	"dir_exists":  noArg(validateDirExists),
+	"cidr":        noArg(validateCIDR),
+}
+
+// validatorRule is a single entry of a validator tag.
+type validatorRule struct {
+	name string
+	arg  string
+}
+
+// parseValidatorTag splits a validator tag such as "nonempty,min=1,max=65535" into rules.
+// A comma can be escaped with a backslash to be used inside an argument.
+func parseValidatorTag(tag string) []validatorRule {
+	var rules []validatorRule
+	var current strings.Builder
+
+	flush := func() {
+		entry := strings.TrimSpace(current.String())
+		current.Reset()
+		if entry == "" {
+			return
+		}
+		name, arg, _ := strings.Cut(entry, "=")
+		rules = append(rules, validatorRule{name: strings.TrimSpace(name), arg: arg})
+	}
+
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			current.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			flush()
+		default:
+			current.WriteByte(tag[i])
+		}
+	}
+	flush()
+
+	return rules
+}
+
+// validate runs every validator of the tag against value and returns the first failure.
+// Custom validators take precedence over built-in ones, unknown names are reported as errors
+// so that a typo does not disable validation.
+func validate(value reflect.Value, tag string, custom map[string]Validator) error {
+	// A custom validator named by the whole tag is run alone, as before validators could be chained,
+	// so that names containing "," or "=" keep their meaning
+	if validator, exists := custom[tag]; exists {
+		return validator(value)
+	}
+
+	for _, rule := range parseValidatorTag(tag) {
+		validator, exists := custom[rule.name]
+		if exists && rule.arg != "" {
+			return fmt.Errorf("invalid %s validator: unexpected argument %q", rule.name, rule.arg)
+		}
+		if !exists {
+			factory, isBuiltin := builtinValidators[rule.name]
+			if !isBuiltin {
+				return fmt.Errorf("unknown validator %q", rule.name)
+			}
+
+			var err error
+			if validator, err = factory(rule.arg); err != nil {
+				return fmt.Errorf("invalid %s validator: %w", rule.name, err)
+			}
+		}
+
+		if err := validator(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// noArg turns a validator without argument into a factory.
+func noArg(validator Validator) validatorFactory {
+	return func(arg string) (Validator, error) {
+		if arg != "" {
+			return nil, fmt.Errorf("unexpected argument %q", arg)
+		}
+		return validator, nil
+	}
+}
+
+// validatorString returns the value as it would appear in the environment.
+func validatorString(value reflect.Value) string {
+	if value.Kind() == reflect.String {
+		return value.String()
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// compareBound compares a number, or the length of a string, slice or map, with bound.
+// It returns -1, 0 or 1 when the value is lower, equal or greater than bound.
+func compareBound(value reflect.Value, bound float64) (int, error) {
+	var actual float64
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		actual = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		actual = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		actual = value.Float()
+	case reflect.String, reflect.Slice, reflect.Map:
+		actual = float64(value.Len())
+	default:
+		return 0, fmt.Errorf("cannot compare %s value", value.Kind())
+	}
+
+	switch {
+	case actual < bound:
+		return -1, nil
+	case actual > bound:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// boundSubject describes what min and max compare, for error messages.
+func boundSubject(value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return "length"
+	default:
+		return "value"
+	}
+}
+
+func minValidator(arg string) (Validator, error) {
+	bound, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(value reflect.Value) error {
+		cmp, err := compareBound(value, bound)
+		if err != nil {
+			return err
+		}
+		if cmp < 0 {
+			return fmt.Errorf("%s must be at least %s", boundSubject(value), arg)
+		}
+		return nil
+	}, nil
+}
+
+func maxValidator(arg string) (Validator, error) {
+	bound, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(value reflect.Value) error {
+		cmp, err := compareBound(value, bound)
+		if err != nil {
+			return err
+		}
+		if cmp > 0 {
+			return fmt.Errorf("%s must be at most %s", boundSubject(value), arg)
+		}
+		return nil
+	}, nil
+}
+
+// oneOfValidator accepts one of the space separated values, e.g. "oneof=debug info warn".
+func oneOfValidator(arg string) (Validator, error) {
+	allowed := strings.Fields(arg)
+	if len(allowed) == 0 {
+		return nil, fmt.Errorf("at least one value is expected")
+	}
+
+	return func(value reflect.Value) error {
+		actual := validatorString(value)
+		for _, candidate := range allowed {
+			if actual == candidate {
+				return nil
+			}
+		}
+		return fmt.Errorf("value must be one of %s", strings.Join(allowed, ", "))
+	}, nil
+}
+
+func regexpValidator(arg string) (Validator, error) {
+	re, err := regexp.Compile(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(value reflect.Value) error {
+		if !re.MatchString(validatorString(value)) {
+			return fmt.Errorf("value must match %s", arg)
+		}
+		return nil
+	}, nil
+}
+
+func validateURL(value reflect.Value) error {
+	u, err := url.Parse(validatorString(value))
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("value must be an absolute URL")
+	}
+	return nil
+}
+
+func validateHostPort(value reflect.Value) error {
+	_, port, err := net.SplitHostPort(validatorString(value))
+	if err != nil {
+		return err
+	}
+	if _, err = strconv.ParseUint(port, 10, 16); err != nil {
+		return fmt.Errorf("invalid port %q", port)
+	}
+	return nil
+}
+
+func validateEmail(value reflect.Value) error {
+	actual := validatorString(value)
+	address, err := mail.ParseAddress(actual)
+	if err != nil || address.Address != actual {
+		return fmt.Errorf("value must be an email address")
+	}
+	return nil
+}
+
+func validatePort(value reflect.Value) error {
+	port, err := strconv.ParseUint(validatorString(value), 10, 16)
+	if err != nil || port == 0 {
+		return fmt.Errorf("value must be a port between 1 and 65535")
+	}
+	return nil
+}
+
+func validateNonEmpty(value reflect.Value) error {
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		if value.Len() == 0 {
+			return fmt.Errorf("value must not be empty")
+		}
+	default:
+		if value.IsZero() {
+			return fmt.Errorf("value must not be empty")
+		}
+	}
+	return nil
+}
+
+func validateFileExists(value reflect.Value) error {
+	info, err := os.Stat(validatorString(value))
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", info.Name())
+	}
+	return nil
+}
+
+func validateDirExists(value reflect.Value) error {
+	info, err := os.Stat(validatorString(value))
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", info.Name())
+	}
+	return nil
+}
+
+func validateCIDR(value reflect.Value) error {
+	_, _, err := net.ParseCIDR(validatorString(value))
+	return err
+}
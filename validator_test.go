@@ -0,0 +1,189 @@
+package dotenv
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseValidatorTag(t *testing.T) {
+	rules := parseValidatorTag(`nonempty, min=1,max=65535,regexp=^a{1\,3}$`)
+
+	expected := []validatorRule{
+		{name: "nonempty"},
+		{name: "min", arg: "1"},
+		{name: "max", arg: "65535"},
+		{name: "regexp", arg: "^a{1,3}$"},
+	}
+
+	if !reflect.DeepEqual(rules, expected) {
+		t.Errorf("parseValidatorTag() = %+v, want %+v", rules, expected)
+	}
+}
+
+func TestBuiltinValidators(t *testing.T) {
+	dir := t.TempDir()
+	file := dir + "/file.txt"
+	if err := os.WriteFile(file, []byte("content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		tag   string
+		value interface{}
+		valid bool
+	}{
+		{"min int valid", "min=1", 1, true},
+		{"min int invalid", "min=1", 0, false},
+		{"max uint valid", "max=65535", uint(65535), true},
+		{"max uint invalid", "max=65535", uint(65536), false},
+		{"min string length", "min=3", "ab", false},
+		{"max float", "max=1.5", 1.6, false},
+		{"chained valid", "min=1,max=10", 5, true},
+		{"chained invalid", "min=1,max=10", 11, false},
+		{"oneof valid", "oneof=debug info warn", "info", true},
+		{"oneof invalid", "oneof=debug info warn", "trace", false},
+		{"regexp valid", "regexp=^v[0-9]+$", "v12", true},
+		{"regexp invalid", "regexp=^v[0-9]+$", "12", false},
+		{"url valid", "url", "https://example.com/path", true},
+		{"url invalid", "url", "example.com", false},
+		{"hostport valid", "hostport", "localhost:8080", true},
+		{"hostport invalid", "hostport", "localhost", false},
+		{"email valid", "email", "user@example.com", true},
+		{"email invalid", "email", "John <user@example.com>", false},
+		{"port valid", "port", 443, true},
+		{"port invalid", "port", 0, false},
+		{"port string invalid", "port", "70000", false},
+		{"nonempty valid", "nonempty", "x", true},
+		{"nonempty invalid", "nonempty", "", false},
+		{"file_exists valid", "file_exists", file, true},
+		{"file_exists directory", "file_exists", dir, false},
+		{"dir_exists valid", "dir_exists", dir, true},
+		{"dir_exists missing", "dir_exists", dir + "/missing", false},
+		{"cidr valid", "cidr", "10.0.0.0/8", true},
+		{"cidr invalid", "cidr", "10.0.0.0", false},
+		{"unknown validator rejected", "unknown", "x", false},
+		{"unknown validator after valid one", "nonempty,mni=1", "x", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(reflect.ValueOf(tt.value), tt.tag, nil)
+			if tt.valid && err != nil {
+				t.Errorf("validate(%v, %q) returned error: %v", tt.value, tt.tag, err)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("validate(%v, %q) returned nil, expected an error", tt.value, tt.tag)
+			}
+		})
+	}
+}
+
+func TestValidateInvalidArgument(t *testing.T) {
+	if err := validate(reflect.ValueOf(1), "min=abc", nil); err == nil {
+		t.Error("Expected error for invalid min argument, got nil")
+	}
+
+	if err := validate(reflect.ValueOf("x"), "url=x", nil); err == nil {
+		t.Error("Expected error for unexpected url argument, got nil")
+	}
+
+	custom := map[string]Validator{"custom": func(reflect.Value) error { return nil }}
+	if err := validate(reflect.ValueOf("x"), "custom=x", custom); err == nil {
+		t.Error("Expected error for unexpected custom validator argument, got nil")
+	}
+
+	if err := validate(reflect.ValueOf("x"), "custom", custom); err != nil {
+		t.Errorf("Expected no error for custom validator, got: %v", err)
+	}
+}
+
+func TestLoadStruct_UnknownValidator(t *testing.T) {
+	_ = os.Setenv("UNKNOWN_VALIDATOR_PORT", "0")
+	defer func() { _ = os.Unsetenv("UNKNOWN_VALIDATOR_PORT") }()
+
+	config := &struct {
+		Port int `env:"UNKNOWN_VALIDATOR_PORT" validator:"mni=1"`
+	}{}
+
+	err := LoadStruct(config)
+	if err == nil || err.Error() != `format validation failed for field Port: unknown validator "mni"` {
+		t.Errorf("Expected unknown validator error, got: %v", err)
+	}
+}
+
+func TestLoadStruct_CustomValidatorNamedByWholeTag(t *testing.T) {
+	_ = os.Setenv("WHOLE_TAG_CODE", "abc")
+	defer func() { _ = os.Unsetenv("WHOLE_TAG_CODE") }()
+
+	var called []string
+	opts := LoadOptions{
+		Validators: map[string]Validator{
+			"len=3,lower": func(value reflect.Value) error {
+				called = append(called, value.String())
+				return nil
+			},
+		},
+	}
+
+	config := &struct {
+		Code string `env:"WHOLE_TAG_CODE" validator:"len=3,lower"`
+	}{}
+
+	if err := LoadStructWithOptions(config, opts); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(called) != 1 || called[0] != "abc" {
+		t.Errorf("Expected the validator to be called once with abc, got: %v", called)
+	}
+}
+
+func TestLoadStruct_BuiltinValidators(t *testing.T) {
+	_ = os.Setenv("BUILTIN_PORT", "70000")
+	_ = os.Setenv("BUILTIN_LEVEL", "info")
+
+	defer func() {
+		_ = os.Unsetenv("BUILTIN_PORT")
+		_ = os.Unsetenv("BUILTIN_LEVEL")
+	}()
+
+	config := &struct {
+		Port  int    `env:"BUILTIN_PORT" validator:"min=1,max=65535"`
+		Level string `env:"BUILTIN_LEVEL" validator:"oneof=debug info warn"`
+		Email string `env:"BUILTIN_EMAIL" default:"admin" validator:"email"`
+	}{}
+
+	err := LoadStruct(config)
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("Expected ErrValidation, got: %v", err)
+	}
+
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) || len(loadErr.Errors) != 2 {
+		t.Fatalf("Expected 2 field errors, got: %v", err)
+	}
+
+	if loadErr.Errors[0].Field != "Port" || loadErr.Errors[1].Field != "Email" {
+		t.Errorf("Unexpected failing fields: %s, %s", loadErr.Errors[0].Field, loadErr.Errors[1].Field)
+	}
+}
+
+func TestLoadStructWithOptions_CustomOverridesBuiltin(t *testing.T) {
+	config := &struct {
+		Email string `env:"BUILTIN_CUSTOM_EMAIL" default:"admin" validator:"email,nonempty"`
+	}{}
+
+	opts := LoadOptions{
+		Validators: map[string]Validator{
+			"email": func(value reflect.Value) error {
+				return nil
+			},
+		},
+	}
+
+	if err := LoadStructWithOptions(config, opts); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+}
@@ -0,0 +1,420 @@
+package dotenv
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultWatchInterval is the polling interval of a Watcher without Interval option.
+const defaultWatchInterval = time.Second
+
+// ChangeKind tells how a variable differs between two reads.
+type ChangeKind int
+
+const (
+	// ChangeAdded is a variable that was not defined before.
+	ChangeAdded ChangeKind = iota
+	// ChangeModified is a variable whose value changed.
+	ChangeModified
+	// ChangeRemoved is a variable that is no longer defined.
+	ChangeRemoved
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeModified:
+		return "modified"
+	case ChangeRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// Change describes a variable that differs between two reads.
+type Change struct {
+	Key  string
+	Kind ChangeKind
+	// Old is the previous value, empty for ChangeAdded
+	Old string
+	// New is the current value, empty for ChangeRemoved
+	New string
+}
+
+// String returns the kind and the key of the change, without the values which may be secret.
+func (c Change) String() string {
+	return c.Kind.String() + " " + c.Key
+}
+
+// Diff returns the changes from old to current, sorted by key.
+func Diff(old, current map[string]string) []Change {
+	var changes []Change
+
+	for key, value := range current {
+		previous, exists := old[key]
+		switch {
+		case !exists:
+			changes = append(changes, Change{Key: key, Kind: ChangeAdded, New: value})
+		case previous != value:
+			changes = append(changes, Change{Key: key, Kind: ChangeModified, Old: previous, New: value})
+		}
+	}
+
+	for key, value := range old {
+		if _, exists := current[key]; !exists {
+			changes = append(changes, Change{Key: key, Kind: ChangeRemoved, Old: value})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+
+	return changes
+}
+
+// WatcherOptions provides configuration options for NewWatcherWithOptions
+type WatcherOptions struct {
+	// Interval is the polling interval, one second when zero
+	Interval time.Duration
+
+	// Apply sets the added and modified variables in the process environment,
+	// and unsets the removed ones, before the subscribers are called
+	Apply bool
+
+	// OnError, when not nil, is called when the files cannot be read, the changes cannot be applied
+	// or a bound struct cannot be loaded. The previous struct is kept, and what failed is retried on the next tick.
+	OnError func(err error)
+}
+
+// Watcher polls .env files and reports the variables that change.
+// Files are read as with Read, later files overriding earlier ones.
+type Watcher struct {
+	locations []string
+	opts      WatcherOptions
+
+	// checkMu serializes Check
+	checkMu sync.Mutex
+
+	// settled holds the values whose changes have been applied and sent to the subscribers.
+	// Only Check uses it, under checkMu.
+	settled map[string]string
+
+	mu          sync.RWMutex
+	values      map[string]string
+	version     int
+	subscribers []func(changes []Change)
+	bindings    []*watcherBinding
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// watcherBinding is a struct re-populated by the Watcher.
+type watcherBinding struct {
+	data interface{}
+	opts LoadOptions
+
+	// stale is set, under the lock of the Watcher, until the struct loads the current values
+	stale bool
+}
+
+// NewWatcher returns a Watcher of the given .env files, with their current variables.
+// Polling starts with Start.
+func NewWatcher(locations ...string) (*Watcher, error) {
+	return NewWatcherWithOptions(WatcherOptions{}, locations...)
+}
+
+// NewWatcherWithOptions returns a Watcher of the given .env files with additional options
+func NewWatcherWithOptions(opts WatcherOptions, locations ...string) (*Watcher, error) {
+	if len(locations) == 0 {
+		return nil, fmt.Errorf("at least one file to watch is expected")
+	}
+
+	if opts.Interval <= 0 {
+		opts.Interval = defaultWatchInterval
+	}
+
+	w := &Watcher{
+		locations: locations,
+		opts:      opts,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	values, err := w.read()
+	if err != nil {
+		return nil, err
+	}
+	w.values = values
+	w.settled = values
+
+	return w, nil
+}
+
+// Files returns the watched files.
+func (w *Watcher) Files() []string {
+	return append([]string(nil), w.locations...)
+}
+
+// Values returns a copy of the variables of the last successful read.
+func (w *Watcher) Values() map[string]string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	values := make(map[string]string, len(w.values))
+	for key, value := range w.values {
+		values[key] = value
+	}
+	return values
+}
+
+// Lookup retrieves the value of the variable named by the key from the last successful read,
+// so that a Watcher can be the Lookuper of LoadOptions, e.g. for a Config reloaded by a subscriber.
+func (w *Watcher) Lookup(key string) (string, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	value, exists := w.values[key]
+	return value, exists
+}
+
+// Subscribe registers fn to be called with the changes of every read that changed a variable.
+func (w *Watcher) Subscribe(fn func(changes []Change)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Bind loads the struct pointed by data from the watched variables, then again on every change.
+// Variables missing from the files are looked up in opts.Lookuper, the process environment when nil.
+// The Lookuper can be the Watcher itself.
+//
+// Every load starts from the zero value of the struct, and is all-or-nothing:
+// the struct is only replaced when it loads without error.
+// Goroutines reading the struct, or the Report of the options, while the Watcher runs must hold RLocker.
+// The Report only holds the sources of the last successful load.
+func (w *Watcher) Bind(data interface{}, opts LoadOptions) error {
+	binding := &watcherBinding{data: data, opts: opts}
+
+	for {
+		w.mu.RLock()
+		values, version := w.values, w.version
+		w.mu.RUnlock()
+
+		// The struct is loaded without holding the lock, as its Lookuper may be the Watcher
+		fresh, report, err := binding.load(values)
+		if err != nil {
+			return err
+		}
+
+		w.mu.Lock()
+		if w.version == version {
+			binding.swap(fresh, report)
+			w.bindings = append(w.bindings, binding)
+			w.mu.Unlock()
+			return nil
+		}
+		// A Check has read new values in the meantime, load them again
+		w.mu.Unlock()
+	}
+}
+
+// RLocker returns a Locker preventing the bound structs from being replaced while it is held.
+func (w *Watcher) RLocker() sync.Locker {
+	return w.mu.RLocker()
+}
+
+// Start polls the files in a new goroutine until Stop is called.
+func (w *Watcher) Start() {
+	w.startOnce.Do(func() {
+		go w.run()
+	})
+}
+
+// Stop ends the polling started by Start and waits for the current check to finish.
+func (w *Watcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+
+	started := true
+	w.startOnce.Do(func() {
+		started = false
+	})
+	if started {
+		<-w.done
+	}
+}
+
+func (w *Watcher) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if _, err := w.Check(); err != nil && w.opts.OnError != nil {
+				w.opts.OnError(err)
+			}
+		}
+	}
+}
+
+// Check reads the files once and returns the changes since the last successful check,
+// applying them as the polling would. It can be called without Start.
+// Calls are serialized, so subscribers must not call Check themselves.
+//
+// Nothing is lost on failure. When the changes cannot be applied to the process environment,
+// they are returned again, and the subscribers called, by the next successful check.
+// A bound struct that fails to load is loaded again by every check until it succeeds.
+func (w *Watcher) Check() ([]Change, error) {
+	w.checkMu.Lock()
+	defer w.checkMu.Unlock()
+
+	current, err := w.read()
+	if err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	if len(Diff(w.values, current)) > 0 {
+		w.values = current
+		w.version++
+		for _, binding := range w.bindings {
+			binding.stale = true
+		}
+	}
+	var stale []*watcherBinding
+	for _, binding := range w.bindings {
+		if binding.stale {
+			stale = append(stale, binding)
+		}
+	}
+	subscribers := make([]func(changes []Change), len(w.subscribers))
+	copy(subscribers, w.subscribers)
+	w.mu.Unlock()
+
+	changes := Diff(w.settled, current)
+	if len(changes) == 0 && len(stale) == 0 {
+		return nil, nil
+	}
+
+	if w.opts.Apply && len(changes) > 0 {
+		if err = applyChanges(changes); err != nil {
+			return changes, err
+		}
+	}
+
+	// Bound structs are loaded without holding the lock, as their Lookuper may be the Watcher
+	var errs []error
+	loaded := make([]reflect.Value, len(stale))
+	reports := make([]*Report, len(stale))
+	for i, binding := range stale {
+		if loaded[i], reports[i], err = binding.load(current); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	w.mu.Lock()
+	for i, binding := range stale {
+		if loaded[i].IsValid() {
+			binding.swap(loaded[i], reports[i])
+			binding.stale = false
+		}
+	}
+	w.mu.Unlock()
+
+	w.settled = current
+	if len(changes) > 0 {
+		for _, subscriber := range subscribers {
+			subscriber(changes)
+		}
+	}
+
+	if len(errs) > 0 {
+		return changes, fmt.Errorf("failed to reload struct: %w", errs[0])
+	}
+
+	return changes, nil
+}
+
+// read returns the variables of the watched files, later files overriding earlier ones.
+func (w *Watcher) read() (map[string]string, error) {
+	values := map[string]string{}
+
+	for _, location := range w.locations {
+		file, err := Read(location)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range file {
+			values[key] = value
+		}
+	}
+
+	return values, nil
+}
+
+// load returns a fresh value of the bound struct populated from values,
+// and the sources of its fields when the options have a Report.
+func (b *watcherBinding) load(values map[string]string) (reflect.Value, *Report, error) {
+	if err := checkStructPointer(b.data); err != nil {
+		return reflect.Value{}, nil, err
+	}
+	target := reflect.ValueOf(b.data)
+
+	opts := b.opts
+	if opts.Lookuper != nil {
+		opts.Lookuper = MultiLookuper{MapLookuper(values), opts.Lookuper}
+	} else {
+		opts.Lookuper = MultiLookuper{MapLookuper(values), OSLookuper{}}
+	}
+	if opts.Report != nil {
+		opts.Report = &Report{}
+	}
+
+	fresh := reflect.New(target.Elem().Type())
+	if err := LoadStructWithOptions(fresh.Interface(), opts); err != nil {
+		return reflect.Value{}, nil, err
+	}
+
+	return fresh, opts.Report, nil
+}
+
+// swap copies a value returned by load over the bound struct, and its sources over the Report.
+func (b *watcherBinding) swap(fresh reflect.Value, report *Report) {
+	reflect.ValueOf(b.data).Elem().Set(fresh.Elem())
+	if b.opts.Report != nil {
+		*b.opts.Report = *report
+	}
+}
+
+// applyChanges reflects the changes in the process environment.
+func applyChanges(changes []Change) error {
+	for _, change := range changes {
+		var err error
+		if change.Kind == ChangeRemoved {
+			err = os.Unsetenv(change.Key)
+		} else {
+			err = os.Setenv(change.Key, change.New)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
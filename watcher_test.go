@@ -0,0 +1,334 @@
+package dotenv
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func writeWatchedFile(t *testing.T, location, content string) {
+	t.Helper()
+	if err := os.WriteFile(location, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := map[string]string{"KEEP": "1", "CHANGE": "a", "REMOVE": "x"}
+	current := map[string]string{"KEEP": "1", "CHANGE": "b", "ADD": "y"}
+
+	expected := []Change{
+		{Key: "ADD", Kind: ChangeAdded, New: "y"},
+		{Key: "CHANGE", Kind: ChangeModified, Old: "a", New: "b"},
+		{Key: "REMOVE", Kind: ChangeRemoved, Old: "x"},
+	}
+
+	if changes := Diff(old, current); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Diff() = %v, want %v", changes, expected)
+	}
+
+	if changes := Diff(old, old); len(changes) != 0 {
+		t.Errorf("Diff() of identical maps = %v, want none", changes)
+	}
+
+	if s := expected[1].String(); s != "modified CHANGE" {
+		t.Errorf("Change.String() = %s, want modified CHANGE", s)
+	}
+}
+
+func TestWatcher_Check(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, ".env")
+	local := filepath.Join(dir, ".env.local")
+	writeWatchedFile(t, base, "WATCH_FEATURE=off\nWATCH_LIMIT=10\nWATCH_OLD=1\n")
+	writeWatchedFile(t, local, "WATCH_LIMIT=20\n")
+
+	defer func() {
+		for _, key := range []string{"WATCH_FEATURE", "WATCH_LIMIT", "WATCH_OLD", "WATCH_NEW"} {
+			_ = os.Unsetenv(key)
+		}
+	}()
+
+	watcher, err := NewWatcherWithOptions(WatcherOptions{Apply: true}, base, local)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if values := watcher.Values(); values["WATCH_LIMIT"] != "20" {
+		t.Errorf("Expected later files to override earlier ones, got: %v", values)
+	}
+
+	var received []Change
+	watcher.Subscribe(func(changes []Change) {
+		received = append(received, changes...)
+	})
+
+	changes, err := watcher.Check()
+	if err != nil || len(changes) != 0 || len(received) != 0 {
+		t.Fatalf("Expected no changes, got: %v, %v", changes, err)
+	}
+
+	_ = os.Setenv("WATCH_OLD", "1")
+	writeWatchedFile(t, base, "WATCH_FEATURE=on\nWATCH_LIMIT=10\nWATCH_NEW=yes\n")
+
+	changes, err = watcher.Check()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := []Change{
+		{Key: "WATCH_FEATURE", Kind: ChangeModified, Old: "off", New: "on"},
+		{Key: "WATCH_NEW", Kind: ChangeAdded, New: "yes"},
+		{Key: "WATCH_OLD", Kind: ChangeRemoved, Old: "1"},
+	}
+	if !reflect.DeepEqual(changes, expected) || !reflect.DeepEqual(received, expected) {
+		t.Errorf("Check() = %v, subscribers got %v, want %v", changes, received, expected)
+	}
+
+	if os.Getenv("WATCH_FEATURE") != "on" || os.Getenv("WATCH_NEW") != "yes" {
+		t.Error("Expected changes to be applied to the environment")
+	}
+	if _, exists := os.LookupEnv("WATCH_OLD"); exists {
+		t.Error("Expected WATCH_OLD to be unset")
+	}
+}
+
+func TestWatcher_Bind(t *testing.T) {
+	location := filepath.Join(t.TempDir(), ".env")
+	writeWatchedFile(t, location, "BIND_FEATURE=on\nBIND_LIMIT=10\n")
+
+	type config struct {
+		Feature bool `env:"BIND_FEATURE"`
+		Limit   int  `env:"BIND_LIMIT" required:"true"`
+	}
+
+	watcher, err := NewWatcher(location)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var cfg config
+	if err = watcher.Bind(&cfg, LoadOptions{}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg != (config{Feature: true, Limit: 10}) {
+		t.Errorf("Bind() = %+v", cfg)
+	}
+
+	writeWatchedFile(t, location, "BIND_FEATURE=off\nBIND_LIMIT=20\n")
+	if _, err = watcher.Check(); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg != (config{Feature: false, Limit: 20}) {
+		t.Errorf("Expected struct to be reloaded, got: %+v", cfg)
+	}
+
+	writeWatchedFile(t, location, "BIND_FEATURE=on\nBIND_LIMIT=twenty\n")
+	if _, err = watcher.Check(); err == nil {
+		t.Error("Expected reload error, got nil")
+	}
+	if cfg != (config{Feature: false, Limit: 20}) {
+		t.Errorf("Expected struct to be kept on error, got: %+v", cfg)
+	}
+}
+
+func TestWatcher_StartStop(t *testing.T) {
+	location := filepath.Join(t.TempDir(), ".env")
+	writeWatchedFile(t, location, "POLL_VALUE=1\n")
+
+	watcher, err := NewWatcherWithOptions(WatcherOptions{Interval: 10 * time.Millisecond}, location)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var once sync.Once
+	notified := make(chan []Change)
+	watcher.Subscribe(func(changes []Change) {
+		once.Do(func() { notified <- changes })
+	})
+
+	watcher.Start()
+	defer watcher.Stop()
+
+	writeWatchedFile(t, location, "POLL_VALUE=2\n")
+
+	select {
+	case changes := <-notified:
+		if len(changes) != 1 || changes[0].New != "2" {
+			t.Errorf("Unexpected changes: %v", changes)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected subscribers to be notified")
+	}
+}
+
+func TestNewWatcher_Errors(t *testing.T) {
+	if _, err := NewWatcher(); err == nil {
+		t.Error("Expected error without files, got nil")
+	}
+
+	if _, err := NewWatcher("test/missing.env"); err == nil {
+		t.Error("Expected error for a missing file, got nil")
+	}
+}
+
+func TestWatcher_BindWithWatcherLookuper(t *testing.T) {
+	location := filepath.Join(t.TempDir(), ".env")
+	writeWatchedFile(t, location, "SELF_LIMIT=10\n")
+
+	watcher, err := NewWatcher(location)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var cfg struct {
+		Limit   int    `env:"SELF_LIMIT"`
+		Missing string `env:"SELF_MISSING" default:"none"`
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		if err := watcher.Bind(&cfg, LoadOptions{Lookuper: watcher}); err != nil {
+			done <- err
+			return
+		}
+		writeWatchedFile(t, location, "SELF_LIMIT=20\n")
+		_, err := watcher.Check()
+		done <- err
+	}()
+
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Bind and Check deadlocked with the Watcher as Lookuper")
+	}
+
+	if cfg.Limit != 20 || cfg.Missing != "none" {
+		t.Errorf("Unexpected struct: %+v", cfg)
+	}
+}
+
+func TestWatcher_ConcurrentCheck(t *testing.T) {
+	location := filepath.Join(t.TempDir(), ".env")
+	writeWatchedFile(t, location, "CONCURRENT_VALUE=1\n")
+
+	watcher, err := NewWatcher(location)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var mu sync.Mutex
+	var notified int
+	watcher.Subscribe(func(changes []Change) {
+		mu.Lock()
+		notified += len(changes)
+		mu.Unlock()
+	})
+
+	writeWatchedFile(t, location, "CONCURRENT_VALUE=2\n")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := watcher.Check(); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if notified != 1 {
+		t.Errorf("Expected the change to be reported once, got %d", notified)
+	}
+}
+
+func TestWatcher_BindReport(t *testing.T) {
+	location := filepath.Join(t.TempDir(), ".env")
+	writeWatchedFile(t, location, "BIND_REPORT=1\n")
+
+	watcher, err := NewWatcher(location)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var cfg struct {
+		Value int `env:"BIND_REPORT"`
+	}
+	report := &Report{}
+	if err = watcher.Bind(&cfg, LoadOptions{Report: report}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	writeWatchedFile(t, location, "BIND_REPORT=2\n")
+	if _, err = watcher.Check(); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(report.Sources) != 1 || report.Sources[0].Value != "2" {
+		t.Errorf("Expected the sources of the last load only, got %+v", report.Sources)
+	}
+}
+
+func TestWatcher_BindRetriesFailedLoad(t *testing.T) {
+	location := filepath.Join(t.TempDir(), ".env")
+	writeWatchedFile(t, location, "RETRY_LIMIT=10\n")
+
+	type config struct {
+		Limit int    `env:"RETRY_LIMIT"`
+		Token string `env:"RETRY_TOKEN" required:"true"`
+	}
+
+	watcher, err := NewWatcher(location)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var notified [][]Change
+	watcher.Subscribe(func(changes []Change) {
+		notified = append(notified, changes)
+	})
+
+	secrets := MapLookuper{"RETRY_TOKEN": "s3cret"}
+	var cfg config
+	if err = watcher.Bind(&cfg, LoadOptions{Lookuper: secrets}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	delete(secrets, "RETRY_TOKEN")
+	writeWatchedFile(t, location, "RETRY_LIMIT=20\n")
+	if _, err = watcher.Check(); err == nil {
+		t.Fatal("Expected reload error, got nil")
+	}
+	if cfg != (config{Limit: 10, Token: "s3cret"}) {
+		t.Errorf("Expected struct to be kept on error, got: %+v", cfg)
+	}
+	if watcher.Values()["RETRY_LIMIT"] != "20" || len(notified) != 1 {
+		t.Errorf("Expected the new values to be read and notified once, got %v and %v", watcher.Values(), notified)
+	}
+
+	// The struct is loaded again without the file changing
+	secrets["RETRY_TOKEN"] = "rotated"
+	changes, err := watcher.Check()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(changes) != 0 || len(notified) != 1 {
+		t.Errorf("Expected the changes not to be sent again, got %v and %v", changes, notified)
+	}
+	if cfg != (config{Limit: 20, Token: "rotated"}) {
+		t.Errorf("Expected struct to be reloaded, got: %+v", cfg)
+	}
+
+	secrets["RETRY_TOKEN"] = "ignored"
+	if _, err = watcher.Check(); err != nil || cfg.Token != "rotated" {
+		t.Errorf("Expected a loaded struct not to be reloaded without changes, got %+v, %v", cfg, err)
+	}
+}